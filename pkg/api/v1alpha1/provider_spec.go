@@ -4,6 +4,10 @@
 
 package v1alpha1
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 const (
 	// GCPServiceAccountJSON is a constant for a key name that is part of the GCP cloud credentials.
 	GCPServiceAccountJSON = "serviceAccountJSON"
@@ -13,6 +17,24 @@ const (
 	// GCPCredentialsConfig is a constant for a key name of a secret containing the GCP credentials configuration.
 	GCPCredentialsConfig = "credentialsConfig"
 
+	// GCPMachineNameKey is the label and metadata key of the name of the Machine an instance belongs to
+	GCPMachineNameKey = "mcm-machine-name"
+	// GCPMachineNamespaceKey is the label and metadata key of the namespace of the Machine an instance belongs to
+	GCPMachineNamespaceKey = "mcm-machine-namespace"
+	// GCPMachineClassKey is the label and metadata key of the name of the MachineClass an instance was created from
+	GCPMachineClassKey = "mcm-machine-class"
+	// GCPMachineDeploymentKey is the label and metadata key of the name of the MachineDeployment an instance belongs to
+	GCPMachineDeploymentKey = "mcm-machine-deployment"
+	// GCPClusterNameKey is the label key of the name of the cluster an instance belongs to
+	GCPClusterNameKey = "mcm-cluster-name"
+	// GCPNodeRoleKey is the label key of the role of the node an instance belongs to
+	GCPNodeRoleKey = "mcm-node-role"
+
+	// GCPClusterTagPrefix is the prefix of the network tag carrying the name of the cluster an instance belongs to
+	GCPClusterTagPrefix = "kubernetes-io-cluster-"
+	// GCPNodeRoleTagPrefix is the prefix of the network tag carrying the role of the node an instance belongs to
+	GCPNodeRoleTagPrefix = "kubernetes-io-role-"
+
 	// GCPDiskTypeScratch is the SCRATCH disk type
 	GCPDiskTypeScratch = "SCRATCH"
 	// GCPDiskTypePersistent is the PERSISTENT disk type
@@ -21,6 +43,69 @@ const (
 	GCPDiskInterfaceNVME = "NVME"
 	// GCPDiskInterfaceSCSI is the SCSI disk interface
 	GCPDiskInterfaceSCSI = "SCSI"
+	// GCPDiskModeReadWrite attaches a disk in read-write mode
+	GCPDiskModeReadWrite = "READ_WRITE"
+	// GCPDiskModeReadOnly attaches a disk in read-only mode
+	GCPDiskModeReadOnly = "READ_ONLY"
+
+	// GCPProvisioningModelStandard is the STANDARD provisioning model
+	GCPProvisioningModelStandard = "STANDARD"
+	// GCPProvisioningModelSpot is the SPOT provisioning model
+	GCPProvisioningModelSpot = "SPOT"
+	// GCPInstanceTerminationActionStop is the STOP instance termination action
+	GCPInstanceTerminationActionStop = "STOP"
+	// GCPInstanceTerminationActionDelete is the DELETE instance termination action
+	GCPInstanceTerminationActionDelete = "DELETE"
+
+	// GCPConfidentialInstanceTypeSEV is the AMD Secure Encrypted Virtualization confidential instance type
+	GCPConfidentialInstanceTypeSEV = "SEV"
+	// GCPConfidentialInstanceTypeSEVSNP is the AMD Secure Encrypted Virtualization - Secure Nested Paging confidential instance type
+	GCPConfidentialInstanceTypeSEVSNP = "SEV_SNP"
+	// GCPConfidentialInstanceTypeTDX is the Intel Trust Domain Extensions confidential instance type
+	GCPConfidentialInstanceTypeTDX = "TDX"
+
+	// GCPConsumeAnyReservation allows the instance to consume any matching reservation
+	GCPConsumeAnyReservation = "ANY_RESERVATION"
+	// GCPConsumeSpecificReservation restricts the instance to consume from the specified reservations only
+	GCPConsumeSpecificReservation = "SPECIFIC_RESERVATION"
+	// GCPConsumeNoReservation prevents the instance from consuming any reservation
+	GCPConsumeNoReservation = "NO_RESERVATION"
+	// GCPReservationNameKey is the reservation affinity key used to target reservations by name
+	GCPReservationNameKey = "googleapis.com/reservation-name"
+
+	// GCPNodeAffinityOperatorIn requires the instance to be scheduled on nodes matching one of the values
+	GCPNodeAffinityOperatorIn = "IN"
+	// GCPNodeAffinityOperatorNotIn requires the instance to be scheduled on nodes matching none of the values
+	GCPNodeAffinityOperatorNotIn = "NOT_IN"
+
+	// GCPNicTypeGVNIC is the gVNIC network interface type
+	GCPNicTypeGVNIC = "GVNIC"
+	// GCPNicTypeVirtioNet is the VirtIO network interface type
+	GCPNicTypeVirtioNet = "VIRTIO_NET"
+	// GCPEgressBandwidthTierDefault is the default egress bandwidth tier
+	GCPEgressBandwidthTierDefault = "DEFAULT"
+	// GCPEgressBandwidthTierTier1 is the Tier_1 egress bandwidth tier
+	GCPEgressBandwidthTierTier1 = "TIER_1"
+	// GCPNetworkTierPremium is the PREMIUM network tier
+	GCPNetworkTierPremium = "PREMIUM"
+	// GCPNetworkTierStandard is the STANDARD network tier
+	GCPNetworkTierStandard = "STANDARD"
+
+	// GCPPerformanceMonitoringUnitArchitectural exposes architecturally defined non-LLC events
+	GCPPerformanceMonitoringUnitArchitectural = "ARCHITECTURAL"
+	// GCPPerformanceMonitoringUnitStandard exposes most documented core/L2 events
+	GCPPerformanceMonitoringUnitStandard = "STANDARD"
+	// GCPPerformanceMonitoringUnitEnhanced exposes most documented core/L2 and LLC events
+	GCPPerformanceMonitoringUnitEnhanced = "ENHANCED"
+	// GCPTurboModeAllCoreMax is the ALL_CORE_MAX turbo mode
+	GCPTurboModeAllCoreMax = "ALL_CORE_MAX"
+
+	// GCPStackTypeIPv4Only is the IPV4_ONLY network stack type
+	GCPStackTypeIPv4Only = "IPV4_ONLY"
+	// GCPStackTypeIPv4IPv6 is the IPV4_IPV6 (dual-stack) network stack type
+	GCPStackTypeIPv4IPv6 = "IPV4_IPV6"
+	// GCPStackTypeIPv6Only is the IPV6_ONLY network stack type
+	GCPStackTypeIPv6Only = "IPV6_ONLY"
 )
 
 // +genclient
@@ -53,7 +138,12 @@ type GCPProviderSpec struct {
 	// Quota of the particular GPU should be available.
 	Gpu *GCPGpu `json:"gpu,omitempty"`
 
-	// Labels: Labels to apply to this instance.
+	// Labels: Labels to apply to this instance. The instance and its new
+	// disks are additionally labeled with the name and namespace of the
+	// Machine, the MachineClass and the MachineDeployment, see
+	// GCPMachineNameKey and related keys. Labels set here take precedence,
+	// except for GCPClusterNameKey and GCPNodeRoleKey, which are derived
+	// from the cluster and role tags and must not be set.
 	Labels map[string]string `json:"labels,omitempty"`
 
 	// MachineType: Full or partial URL of the machine type resource to use
@@ -80,6 +170,12 @@ type GCPProviderSpec struct {
 	// machine types.
 	MachineType string `json:"machineType"`
 
+	// CustomMachineType: A custom machine type the name of the machine type is
+	// generated from, e.g. n2-custom-8-32768. Cannot be combined with
+	// machineType.
+	// +optional
+	CustomMachineType *GCPCustomMachineType `json:"customMachineType,omitempty"`
+
 	// Metadata: The metadata key/value pairs assigned to this instance.
 	// This includes custom metadata and predefined keys.
 	Metadata []*GCPMetadata `json:"metadata,omitempty"`
@@ -109,10 +205,30 @@ type GCPProviderSpec struct {
 	// instance. See Service Accounts for more information.
 	ServiceAccounts []GCPServiceAccount `json:"serviceAccounts"`
 
-	// Tags: to be placed on the VM
+	// Tags: to be placed on the VM. They also replace the tags of a
+	// SourceInstanceTemplate. The VMs of the cluster are identified by the
+	// tag with prefix GCPClusterTagPrefix and the one with prefix
+	// GCPNodeRoleTagPrefix, at most one of each is allowed. The machines of
+	// a class without them cannot be listed.
 	// +optional
 	Tags []string `json:"tags,omitempty"`
 
+	// SanitizeLabelsAndTags: Whether to convert the labels of the instance
+	// and its disks and the network tags into valid GCE labels and tags
+	// instead of rejecting them. Illegal characters are replaced by dashes,
+	// upper case letters are lowercased, values are truncated to 63
+	// characters and keys and tags not starting with a letter are prefixed
+	// with "x". If two labels end up with the same key, the first key in
+	// lexical order wins.
+	// +optional
+	SanitizeLabelsAndTags bool `json:"sanitizeLabelsAndTags,omitempty"`
+
+	// ResourceManagerTags: Resource Manager tags to bind to the instance, as
+	// opposed to the network tags in tags. Keys must be in the format
+	// tagKeys/{tag_key_id} and values in the format tagValues/{tag_value_id}.
+	// +optional
+	ResourceManagerTags map[string]string `json:"resourceManagerTags,omitempty"`
+
 	// Zone: in which instance is to be deployed
 	Zone string `json:"zone"`
 
@@ -123,6 +239,69 @@ type GCPProviderSpec struct {
 	// AdvancedMachineFeatures specifies advanced options like BIOS or OS configuration.
 	// +optional
 	AdvancedMachineFeatures *AdvancedMachineFeatures `json:"advancedMachineFeatures,omitempty"`
+
+	// ConfidentialInstanceConfig enables confidential computing for the instance.
+	// +optional
+	ConfidentialInstanceConfig *ConfidentialInstanceConfig `json:"confidentialInstanceConfig,omitempty"`
+
+	// ReservationAffinity specifies the reservations that this instance can consume from.
+	// +optional
+	ReservationAffinity *GCPReservationAffinity `json:"reservationAffinity,omitempty"`
+
+	// ResourcePolicies: Resource policies, e.g. compact placement or instance
+	// schedule policies, applied to this instance. A policy can be given by its
+	// short name, which is resolved against the region of the instance, or as a
+	// full or partial URL. For example, the following are all valid values:
+	// - my-placement-policy
+	// - projects/project/regions/region/resourcePolicies/my-placement-policy
+	// - https://www.googleapis.com/compute/v1/projects/project/regions/region/resourcePolicies/my-placement-policy
+	// +optional
+	ResourcePolicies []string `json:"resourcePolicies,omitempty"`
+
+	// NetworkPerformanceConfig configures the network performance of the instance.
+	// +optional
+	NetworkPerformanceConfig *GCPNetworkPerformanceConfig `json:"networkPerformanceConfig,omitempty"`
+
+	// Hostname: A Go template of the hostname of the instance, e.g.
+	// {{.MachineName}}.nodes.example.com. The template can refer to
+	// .MachineName, .MachineNamespace, .Zone and .Region. The rendered
+	// hostname must be a RFC 1035 compliant FQDN with at least two labels and
+	// at most 253 characters. If not set, the default hostname
+	// <name>.c.<project>.internal is used.
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// SourceInstanceTemplate: An instance template to create the instance
+	// from. Fields set in this provider spec override the values of the
+	// template, disks, machineType and networkInterfaces may be omitted to use
+	// the ones of the template. The scheduling is only overridden if any of its
+	// fields is set, the tags are always overridden. The labels of the
+	// template are replaced by the labels identifying the machine and the
+	// labels of this provider spec, they are not merged. Disks taken from the
+	// template keep the labels of the template. The metadata of the template,
+	// e.g. ssh-keys or startup scripts, is replaced as well, as the instance
+	// always carries the user data and the identity of the machine.
+	// canIpForward and deletionProtection can only enable the setting, a
+	// template that enables them cannot be overridden with false. A global
+	// template can be given by its name, which is resolved against the
+	// project of the credentials, or as a full or partial URL. For example,
+	// the following are all valid values:
+	// - my-template
+	// - projects/project/global/instanceTemplates/my-template
+	// - projects/project/regions/region/instanceTemplates/my-template
+	// +optional
+	SourceInstanceTemplate string `json:"sourceInstanceTemplate,omitempty"`
+}
+
+// GCPNetworkPerformanceConfig describes the network performance configuration for GCP.
+type GCPNetworkPerformanceConfig struct {
+	// TotalEgressBandwidthTier: The egress bandwidth tier of the instance.
+	// TIER_1 requires all network interfaces to use the GVNIC nicType.
+	//
+	// Possible values:
+	//   "DEFAULT"
+	//   "TIER_1"
+	TotalEgressBandwidthTier string `json:"totalEgressBandwidthTier"`
 }
 
 // ShieldedInstanceConfiguration describes the shielded instance configuration for GCE VMs
@@ -140,6 +319,19 @@ type ShieldedInstanceConfiguration struct {
 	Vtpm *bool `json:"vtpm,omitempty"`
 }
 
+// ConfidentialInstanceConfig describes the confidential computing configuration for GCE VMs
+type ConfidentialInstanceConfig struct {
+	// ConfidentialInstanceType: Defines the type of technology used by the
+	// confidential instance. The type must be supported by the machine series
+	// and requires onHostMaintenance to be TERMINATE.
+	//
+	// Possible values:
+	//   "SEV" - AMD Secure Encrypted Virtualization (N2D, C2D, C3D, C4D).
+	//   "SEV_SNP" - AMD Secure Encrypted Virtualization - Secure Nested Paging (N2D).
+	//   "TDX" - Intel Trust Domain Extensions (C3).
+	ConfidentialInstanceType string `json:"confidentialInstanceType"`
+}
+
 // AdvancedMachineFeatures specifies options for controlling advanced machine
 // features. Options that would traditionally be configured in a BIOS belong
 // here. Features that require operating system support may have corresponding
@@ -149,13 +341,49 @@ type AdvancedMachineFeatures struct {
 	// EnableNestedVirtualization: Whether to enable nested virtualization or not
 	// (default is false).
 	EnableNestedVirtualization bool `json:"enableNestedVirtualization,omitempty"`
+
+	// EnableUefiNetworking: Whether to enable UEFI networking for instance
+	// creation.
+	// +optional
+	EnableUefiNetworking bool `json:"enableUefiNetworking,omitempty"`
+
+	// PerformanceMonitoringUnit: Type of Performance Monitoring Unit requested
+	// on the instance.
+	//
+	// Possible values:
+	//   "ARCHITECTURAL"
+	//   "STANDARD"
+	//   "ENHANCED"
+	// +optional
+	PerformanceMonitoringUnit string `json:"performanceMonitoringUnit,omitempty"`
+
+	// ThreadsPerCore: The number of threads per physical core, either 1 or 2.
+	// To disable simultaneous multithreading (SMT) set this to 1. If unset,
+	// the maximum number of threads supported per core by the underlying
+	// processor is assumed.
+	// +optional
+	ThreadsPerCore int64 `json:"threadsPerCore,omitempty"`
+
+	// TurboMode: Turbo frequency mode to use for the instance. The only
+	// supported mode is ALL_CORE_MAX, if unset the platform-specific default
+	// turbo mode is used.
+	// +optional
+	TurboMode string `json:"turboMode,omitempty"`
+
+	// VisibleCoreCount: The number of physical cores to expose to the
+	// instance. Multiply by the number of threads per core to compute the
+	// total number of vCPUs exposed to the instance. If unset, the number of
+	// cores is inferred from the nominal vCPU count of the machine type.
+	// +optional
+	VisibleCoreCount int64 `json:"visibleCoreCount,omitempty"`
 }
 
 // GCPDisk describes disks for GCP.
 type GCPDisk struct {
 	// AutoDelete: Specifies whether the disk will be auto-deleted when the
 	// instance is deleted (but not when the disk is detached from the
-	// instance).
+	// instance). Defaults to true, except for existing disks attached via
+	// source, which are kept.
 	AutoDelete *bool `json:"autoDelete"`
 
 	// Boot: Indicates that this is a boot disk. The virtual machine will
@@ -255,13 +483,94 @@ type GCPDisk struct {
 	// StoragePool in which the new disk is created.
 	// You can provide this as a partial or full URL to the resource. For example
 	// https://www.googleapis.com/compute/v1/projects/project/zones/zone
+	// Only supported for Hyperdisk types.
 	// +optional
 	StoragePool *string `json:"storagePool,omitempty"`
+
+	// ReplicaZones: The two zones a regional persistent disk is replicated
+	// to, one of them must be the zone of the instance. Only supported for the
+	// disk types pd-standard, pd-balanced, pd-ssd and
+	// hyperdisk-balanced-high-availability.
+	// +optional
+	ReplicaZones []string `json:"replicaZones,omitempty"`
+
+	// ResourceManagerTags: Resource Manager tags to bind to the new disk. Keys
+	// must be in the format tagKeys/{tag_key_id} and values in the format
+	// tagValues/{tag_value_id}. Not supported for SCRATCH disks and existing
+	// disks referenced by source.
+	// +optional
+	ResourceManagerTags map[string]string `json:"resourceManagerTags,omitempty"`
+
+	// Source: An existing persistent disk to attach to the instance instead
+	// of creating a new one. You can provide the disk name, which is resolved
+	// against the zone of the instance, or a partial or full URL. For example:
+	// - projects/project/zones/zone/disks/disk
+	// - disk
+	// A disk attached in READ_WRITE mode can only be attached to a single
+	// instance. Cannot be combined with image, sourceSnapshot or sourceDisk.
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Mode: The mode in which to attach this disk. The default is READ_WRITE,
+	// READ_ONLY is only supported for existing disks referenced by source.
+	//
+	// Possible values:
+	//   "READ_ONLY"
+	//   "READ_WRITE"
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// SourceSnapshot: The source snapshot to create this disk from. You can
+	// provide the snapshot name or a partial or full URL. For example:
+	// - projects/project/global/snapshots/snapshot
+	// - global/snapshots/snapshot
+	// - snapshot
+	// +optional
+	SourceSnapshot string `json:"sourceSnapshot,omitempty"`
+
+	// SourceDisk: The source disk to create this disk from. You can provide
+	// the disk name, which is resolved against the zone of the instance, or a
+	// partial or full URL. For example:
+	// - projects/project/zones/zone/disks/disk
+	// - projects/project/regions/region/disks/disk
+	// - disk
+	// The clone is created as <machine name>-disk-<index> before the instance
+	// and attached to it. It is deleted again if the instance cannot be
+	// created, or when the machine is deleted without an instance.
+	// +optional
+	SourceDisk string `json:"sourceDisk,omitempty"`
+}
+
+// GCPCustomMachineType describes a custom machine type.
+type GCPCustomMachineType struct {
+	// Series: The machine series of the custom machine type.
+	//
+	// Possible values:
+	//   "n1"
+	//   "n2"
+	//   "n2d"
+	//   "n4"
+	//   "e2"
+	Series string `json:"series"`
+
+	// Cpus: The number of vCPUs. The allowed values depend on the series.
+	Cpus int64 `json:"cpus"`
+
+	// MemoryMiB: The memory in MiB, it must be a multiple of 256. The allowed
+	// memory per vCPU depends on the series.
+	MemoryMiB int64 `json:"memoryMiB"`
+
+	// ExtendedMemory: Whether to allow more memory per vCPU than the series
+	// supports by default. Only supported by the n1, n2 and n2d series.
+	// +optional
+	ExtendedMemory bool `json:"extendedMemory,omitempty"`
 }
 
 // GCPDiskEncryption holds references to encryption data
 type GCPDiskEncryption struct {
-	// KmsKeyName: key name of the cloud kms disk encryption key. Not optional
+	// KmsKeyName: key name of the cloud kms disk encryption key. Required unless
+	// CustomerSuppliedKey or SourceImageKey is set. If only SourceImageKey is
+	// set, the disk is encrypted with a Google-managed key
 	KmsKeyName string `json:"kmsKeyName"`
 
 	// KmsKeyServiceAccount: The service account granted the `roles/cloudkms.cryptoKeyEncrypterDecrypter` for the key name.
@@ -271,6 +580,29 @@ type GCPDiskEncryption struct {
 	//  gcloud projects add-iam-policy-binding projectId --member
 	//	serviceAccount:name@projectIdgserviceaccount.com --role roles/cloudkms.cryptoKeyEncrypterDecrypter
 	KmsKeyServiceAccount string `json:"kmsKeyServiceAccount"`
+
+	// CustomerSuppliedKey: customer-supplied key the disk is encrypted with instead of a cloud kms key.
+	// Cannot be combined with KmsKeyName.
+	// +optional
+	CustomerSuppliedKey *GCPCustomerSuppliedKey `json:"customerSuppliedKey,omitempty"`
+
+	// SourceImageKey: customer-supplied key the source image of the disk is encrypted with.
+	// Only applicable to disks created from an image.
+	// +optional
+	SourceImageKey *GCPCustomerSuppliedKey `json:"sourceImageKey,omitempty"`
+}
+
+// GCPCustomerSuppliedKey references a customer-supplied encryption key stored in the MachineClass secret. The
+// key material itself is never part of the ProviderSpec. Exactly one of RawKey and RsaEncryptedKey must be set.
+type GCPCustomerSuppliedKey struct {
+	// RawKey: name of the secret data entry holding the 256-bit AES key, encoded in RFC 4648 base64.
+	// +optional
+	RawKey string `json:"rawKey,omitempty"`
+
+	// RsaEncryptedKey: name of the secret data entry holding the 256-bit AES key wrapped with the
+	// Google-provided RSA public key, encoded in RFC 4648 base64.
+	// +optional
+	RsaEncryptedKey string `json:"rsaEncryptedKey,omitempty"`
 }
 
 // GCPMetadata describes metadata for GCP.
@@ -304,11 +636,13 @@ type GCPNetworkInterface struct {
 	// specified when creating a firewall rule, the default network
 	// global/networks/default is used.
 	//
-	// If you specify this property, you can specify the network as a full
-	// or partial URL. For example, the following are all valid URLs:
+	// If you specify this property, you can specify the network by its name,
+	// which is resolved against the hostProject or the project of the
+	// credentials, or as a full or partial URL. For example, the following are
+	// all valid values:
 	// - https://www.googleapis.com/compute/v1/projects/project/global/networks/network
 	// - projects/project/global/networks/network
-	// - global/networks/default
+	// - network
 	Network string `json:"network,omitempty"`
 
 	// Subnetwork: The URL of the Subnetwork resource for this instance. If
@@ -316,14 +650,22 @@ type GCPNetworkInterface struct {
 	// If the network is in auto subnet mode, providing the subnetwork is
 	// optional. If the network is in custom subnet mode, then this field
 	// should be specified. If you specify this property, you can specify
-	// the subnetwork as a full or partial URL. For example, the following
-	// are all valid URLs:
+	// the subnetwork by its name, which is resolved against the region and
+	// the hostProject, or as a full or partial URL. For example, the
+	// following are all valid values:
 	// - https://www.googleapis.com/compute/v1/projects/project/regions/region/subnetworks/subnetwork
-	// - regions/region/subnetworks/subnetwork
+	// - projects/project/regions/region/subnetworks/subnetwork
+	// - subnetwork
 	Subnetwork string `json:"subnetwork,omitempty"`
 
-	// StackType specifies the network stack type, such as IPV4_ONLY or IPV4_IPV6,
-	// to indicate the protocol version(s) supported for this network.
+	// HostProject: The Shared VPC host project of the network and subnetwork.
+	// Only used to resolve network and subnetwork names, if not set the
+	// project of the credentials is used.
+	// +optional
+	HostProject string `json:"hostProject,omitempty"`
+
+	// StackType specifies the network stack type, such as IPV4_ONLY, IPV4_IPV6
+	// or IPV6_ONLY, to indicate the protocol version(s) supported for this network.
 	StackType string `json:"stackType"`
 
 	// Ipv6AccessType defines the type of IPv6 access enabled, such as
@@ -336,11 +678,75 @@ type GCPNetworkInterface struct {
 
 	// IpCidrRange represents the mask size of the secondary range in a GCP subnet,
 	// which will be allocated and used by the virtual machines for internal networking.
+	// Cannot be set on IPV6_ONLY interfaces, which use aliasIPRanges instead.
 	IpCidrRange string `json:"ipCidrRange"`
 
 	// SubnetworkRangeName specifies the secondary IPv4 range in the subnetwork,
 	// which will serve as the IPv4 Pod CIDR for the dual-stack shoot cluster.
+	// Cannot be set on IPV6_ONLY interfaces, which use aliasIPRanges instead.
 	SubnetworkRangeName string `json:"subnetworkRangeName"`
+
+	// AliasIPRanges: A list of alias IP ranges to assign to the instance, e.g.
+	// to allocate separate ranges for several CNIs. Cannot be combined with
+	// ipCidrRange and subnetworkRangeName.
+	// +optional
+	AliasIPRanges []GCPAliasIPRange `json:"aliasIPRanges,omitempty"`
+
+	// NicType: The type of vNIC to be used on this interface.
+	//
+	// Possible values:
+	//   "GVNIC"
+	//   "VIRTIO_NET"
+	// +optional
+	NicType string `json:"nicType,omitempty"`
+
+	// QueueCount: The networking queue count for this interface. Both Rx and
+	// Tx queues are set to this number.
+	// +optional
+	QueueCount int64 `json:"queueCount,omitempty"`
+
+	// NetworkIP: A static internal IPv4 address to assign to the instance. If
+	// not specified, an unused internal IP is assigned by the system.
+	// +optional
+	NetworkIP string `json:"networkIP,omitempty"`
+
+	// NatIP: A static external IPv4 address, or the name of a reserved
+	// external address in the region of the instance, to attach to the
+	// instance. If not specified, an ephemeral external IP is assigned.
+	// Requires disableExternalIP to be false.
+	// +optional
+	NatIP string `json:"natIP,omitempty"`
+
+	// NetworkTier: The network tier of the external IP.
+	// Requires disableExternalIP to be false and cannot be set on IPV6_ONLY
+	// interfaces.
+	//
+	// Possible values:
+	//   "PREMIUM"
+	//   "STANDARD"
+	// +optional
+	NetworkTier string `json:"networkTier,omitempty"`
+
+	// PublicPtrDomainName: The DNS domain name for the public PTR record of
+	// the external IP. Requires disableExternalIP to be false and cannot be set
+	// on IPV6_ONLY interfaces.
+	// +optional
+	PublicPtrDomainName string `json:"publicPtrDomainName,omitempty"`
+}
+
+// GCPAliasIPRange describes an alias IP range of a network interface.
+type GCPAliasIPRange struct {
+	// IpCidrRange: The IP alias range to allocate for the instance. It can be
+	// a netmask like /24, in which case a range of that size is allocated from
+	// the subnetwork range, or a CIDR like 10.1.2.0/24. IPv6 prefixes up to
+	// /128 are allowed on IPV6_ONLY interfaces.
+	IpCidrRange string `json:"ipCidrRange"`
+
+	// SubnetworkRangeName: The name of the secondary range of the subnetwork
+	// to allocate the IP alias range from. If not set, the primary range of
+	// the subnetwork is used.
+	// +optional
+	SubnetworkRangeName string `json:"subnetworkRangeName,omitempty"`
 }
 
 // GCPScheduling describes scheduling configuration for GCP.
@@ -370,6 +776,91 @@ type GCPScheduling struct {
 	// only be set during instance creation, it cannot be set or changed
 	// after the instance has been created.
 	Preemptible bool `json:"preemptible"`
+
+	// ProvisioningModel: Specifies the provisioning model of the instance.
+	// Spot VMs require automaticRestart to be false and onHostMaintenance
+	// to be TERMINATE.
+	//
+	// Possible values:
+	//   "STANDARD"
+	//   "SPOT"
+	// +optional
+	ProvisioningModel string `json:"provisioningModel,omitempty"`
+
+	// InstanceTerminationAction: Specifies the termination action for the
+	// instance when it is preempted or reaches its maxRunDuration or
+	// terminationTime. Defaults to STOP for Spot VMs.
+	//
+	// Possible values:
+	//   "STOP"
+	//   "DELETE"
+	// +optional
+	InstanceTerminationAction string `json:"instanceTerminationAction,omitempty"`
+
+	// MaxRunDuration: Specifies the duration after which the instance is
+	// terminated using the instanceTerminationAction. Must be between 30s
+	// and 120 days. Cannot be combined with terminationTime.
+	// +optional
+	MaxRunDuration *metav1.Duration `json:"maxRunDuration,omitempty"`
+
+	// TerminationTime: Specifies the timestamp at which the instance is
+	// terminated using the instanceTerminationAction. Cannot be combined
+	// with maxRunDuration.
+	// +optional
+	TerminationTime *metav1.Time `json:"terminationTime,omitempty"`
+
+	// NodeAffinities: A set of node affinity and anti-affinity configurations
+	// used to schedule the instance on sole-tenant nodes.
+	// +optional
+	NodeAffinities []GCPSchedulingNodeAffinity `json:"nodeAffinities,omitempty"`
+
+	// MinNodeCpus: The minimum number of virtual CPUs this instance will
+	// consume when running on a sole-tenant node.
+	// +optional
+	MinNodeCpus int64 `json:"minNodeCpus,omitempty"`
+}
+
+// GCPSchedulingNodeAffinity describes a node affinity of an instance for GCP sole-tenant nodes.
+type GCPSchedulingNodeAffinity struct {
+	// Key: Corresponds to the label key of the node resource, e.g.
+	// compute.googleapis.com/node-group-name.
+	Key string `json:"key"`
+
+	// Operator: Defines the operation of node selection.
+	//
+	// Possible values:
+	//   "IN" - Requires the instance to be scheduled on a node with a matching label value.
+	//   "NOT_IN" - Requires the instance to be scheduled on a node without a matching label value.
+	Operator string `json:"operator"`
+
+	// Values: Corresponds to the label values of the node resource.
+	Values []string `json:"values"`
+}
+
+// GCPReservationAffinity describes the reservation affinity of an instance for GCP.
+type GCPReservationAffinity struct {
+	// ConsumeReservationType: Specifies the type of reservation from which this
+	// instance can consume resources.
+	//
+	// Possible values:
+	//   "ANY_RESERVATION" - Consume any allocation available (default).
+	//   "SPECIFIC_RESERVATION" - Must consume from one of the reservations given in values.
+	//   "NO_RESERVATION" - Do not consume from any allocated capacity.
+	ConsumeReservationType string `json:"consumeReservationType"`
+
+	// Key: Corresponds to the label key of a reservation resource. Defaults to
+	// googleapis.com/reservation-name for SPECIFIC_RESERVATION, which targets
+	// reservations by name.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// Values: Corresponds to the label values of a reservation resource. This can
+	// be either the name of a reservation in the same project or
+	// projects/different-project/reservations/some-reservation-name to target a
+	// shared reservation in the same zone but in a different project.
+	// Required for SPECIFIC_RESERVATION.
+	// +optional
+	Values []string `json:"values,omitempty"`
 }
 
 // GCPServiceAccount describes service accounts for GCP.
@@ -4,7 +4,11 @@
 
 package errors
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+)
 
 // MachineNotFoundError is used to indicate not found error in PluginSPI
 type MachineNotFoundError struct {
@@ -27,3 +31,19 @@ type MachineResourceExhaustedError struct {
 func (e *MachineResourceExhaustedError) Error() string {
 	return e.Msg
 }
+
+// MachineNotRunningError is used to indicate that the VM exists, but is not running in PluginSPI
+type MachineNotRunningError struct {
+	// Name is the machine name
+	Name string
+	// Status is the status of the VM
+	Status string
+	// Code is the machine code the status is reported with
+	Code codes.Code
+	// Msg describes what the status means for the machine
+	Msg string
+}
+
+func (e *MachineNotRunningError) Error() string {
+	return fmt.Sprintf("instance %q is in status %s, %s", e.Name, e.Status, e.Msg)
+}
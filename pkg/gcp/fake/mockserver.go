@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -21,10 +22,24 @@ import (
 // Instances stores and manages the instances during create,delete and list calls
 var Instances []*compute.Instance
 
+// Disks stores the disks inserted and deleted by disk calls
+var Disks []*compute.Disk
+
 // DefaultMockPageSize is the default page size used by the mock server for pagination testing.
 // This can be modified in tests to simulate different pagination scenarios
 var DefaultMockPageSize = 500
 
+// StatusLabel is the instance label the mock server reads the status of a created instance from, instances without
+// this label are RUNNING
+const StatusLabel = "mock-status"
+
+// FailedOperationLabel is the instance label that makes the mock server fail the insert operation of an instance
+// after the insert call was accepted, the instance is not created
+const FailedOperationLabel = "mock-failed-operation"
+
+// failedOperationName is the name of the operations reported as failed
+const failedOperationName = "failed-operation"
+
 var singleConnHandler = make(chan struct{})
 
 type httpHandler struct {
@@ -91,6 +106,27 @@ func handleCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	operation := compute.Operation{
+		Status:        "RUNNING",
+		OperationType: "insert",
+		Kind:          "compute#operation",
+	}
+
+	// disk insert calls do not create instances
+	if decodeOperationType(r, 1) == "disks" {
+		var disk *compute.Disk
+		if err := json.NewDecoder(r.Body).Decode(&disk); err != nil {
+			fmt.Println("Error in unmarshalling request body", err)
+		}
+		if slices.ContainsFunc(Disks, func(d *compute.Disk) bool { return d.Name == disk.Name }) {
+			http.Error(w, "Disk already exists", http.StatusConflict)
+			return
+		}
+		Disks = append(Disks, disk)
+		_ = json.NewEncoder(w).Encode(operation)
+		return
+	}
+
 	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		fmt.Println("Error in reading request body", err)
@@ -101,16 +137,53 @@ func handleCreate(w http.ResponseWriter, r *http.Request) {
 		fmt.Println("Error in unmarshalling request body", err)
 	}
 
-	operation := compute.Operation{
-		Status:        "RUNNING",
-		OperationType: "insert",
-		Kind:          "compute#operation",
+	if _, ok := instance.Labels[FailedOperationLabel]; ok {
+		operation.Name = failedOperationName
+		_ = json.NewEncoder(w).Encode(operation)
+		return
+	}
+
+	instance.Status = "RUNNING"
+	if status, ok := instance.Labels[StatusLabel]; ok {
+		instance.Status = strings.ToUpper(status)
+	}
+	for i, nic := range instance.NetworkInterfaces {
+		if nic.NetworkIP == "" {
+			nic.NetworkIP = fmt.Sprintf("10.250.0.%d", i+2)
+		}
+		for _, accessConfig := range nic.AccessConfigs {
+			if accessConfig.NatIP == "" {
+				accessConfig.NatIP = fmt.Sprintf("198.51.100.%d", i+2)
+			}
+		}
 	}
 
 	Instances = append(Instances, instance)
 	_ = json.NewEncoder(w).Encode(operation)
 }
 
+func handleGet(w http.ResponseWriter, r *http.Request) {
+	name := decodeOperationType(r, 1)
+	for _, instance := range Instances {
+		if instance.Name == name {
+			_ = json.NewEncoder(w).Encode(instance)
+			return
+		}
+	}
+	http.Error(w, "Instance not found", http.StatusNotFound)
+}
+
+func handleGetDisk(w http.ResponseWriter, r *http.Request) {
+	name := decodeOperationType(r, 1)
+	for _, disk := range Disks {
+		if disk.Name == name {
+			_ = json.NewEncoder(w).Encode(disk)
+			return
+		}
+	}
+	http.Error(w, "Disk not found", http.StatusNotFound)
+}
+
 func handleList(w http.ResponseWriter, r *http.Request) {
 	//error mock handling for create/delete calls
 	if decodeOperationType(r, 3) == "invalid list" {
@@ -130,7 +203,23 @@ func handleList(w http.ResponseWriter, r *http.Request) {
 			OperationType: "insert",
 			Kind:          "compute#operation",
 		}
+		if decodeOperationType(r, 1) == failedOperationName {
+			operation.Error = &compute.OperationError{
+				Errors: []*compute.OperationErrorErrors{{Code: "UNSUPPORTED_OPERATION", Message: "mock insert operation failed"}},
+			}
+		}
 		_ = json.NewEncoder(w).Encode(operation)
+	} else if decodeOperationType(r, 2) == "disks" { // get call for a single disk
+		handleGetDisk(w, r)
+	} else if decodeOperationType(r, 2) == "addresses" { // get call for a reserved address
+		address := compute.Address{
+			Name:    decodeOperationType(r, 1),
+			Address: "203.0.113.10",
+			Kind:    "compute#address",
+		}
+		_ = json.NewEncoder(w).Encode(address)
+	} else if decodeOperationType(r, 2) == "instances" { // get call for a single VM
+		handleGet(w, r)
 	} else { // this is the regular list call handling for VM
 		pageToken := r.URL.Query().Get("pageToken")
 		maxResults := DefaultMockPageSize
@@ -175,6 +264,20 @@ func handleDelete(w http.ResponseWriter, r *http.Request) {
 		Instances = nil
 	}
 
+	if decodeOperationType(r, 2) == "disks" {
+		name := decodeOperationType(r, 1)
+		Disks = slices.DeleteFunc(Disks, func(disk *compute.Disk) bool { return disk.Name == name })
+	}
+
+	if decodeOperationType(r, 2) == "instances" {
+		name := decodeOperationType(r, 1)
+		if !slices.ContainsFunc(Instances, func(instance *compute.Instance) bool { return instance.Name == name }) {
+			http.Error(w, "Instance not found", http.StatusNotFound)
+			return
+		}
+		Instances = slices.DeleteFunc(Instances, func(instance *compute.Instance) bool { return instance.Name == name })
+	}
+
 	operation := compute.Operation{
 		Status:        "RUNNING",
 		OperationType: "delete",
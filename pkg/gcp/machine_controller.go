@@ -25,6 +25,7 @@ import (
 	"fmt"
 
 	"context"
+	errors2 "github.com/gardener/machine-controller-manager-provider-gcp/pkg/gcp/errors"
 	"github.com/gardener/machine-controller-manager-provider-gcp/pkg/instrument"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
@@ -38,11 +39,12 @@ const (
 	// gcePDDriverName is the name of the CSI driver for GCE PD
 	gcePDDriverName = "pd.csi.storage.gke.io"
 	// labels used for recording prometheus metrics
-	createMachineOperationLabel    = "create_machine"
-	deleteMachineOperationLabel    = "delete_machine"
-	getMachineStatusOperationLabel = "get_machine_status"
-	listMachinesOperationLabel     = "list_machine"
-	getVolumeIDsOperationLabel     = "get_volume_ids"
+	createMachineOperationLabel     = "create_machine"
+	initializeMachineOperationLabel = "initialize_machine"
+	deleteMachineOperationLabel     = "delete_machine"
+	getMachineStatusOperationLabel  = "get_machine_status"
+	listMachinesOperationLabel      = "list_machine"
+	getVolumeIDsOperationLabel      = "get_volume_ids"
 )
 
 // CreateMachine handles a machine creation request
@@ -91,10 +93,10 @@ func (ms *MachinePlugin) CreateMachine(ctx context.Context, req *driver.CreateMa
 	if err = validateProviderSpec(providerSpec); err != nil {
 		return nil, prepareErrorf(err, "Create machine %q failed on validateProviderSpec", req.Machine.Name)
 	}
-	if err = validateSecret(req.Secret); err != nil {
+	if err = validateSecret(req.Secret, providerSpec); err != nil {
 		return nil, prepareErrorf(err, "Create machine %q failed on validateSecret", req.Machine.Name)
 	}
-	providerID, err := ms.CreateMachineUtil(ctx, req.Machine.Name, providerSpec, req.Secret)
+	providerID, err := ms.CreateMachineUtil(ctx, req.Machine, req.MachineClass, providerSpec, req.Secret)
 	if err != nil {
 		return nil, prepareErrorf(err, "Create machine %q failed", req.Machine.Name)
 	}
@@ -109,9 +111,56 @@ func (ms *MachinePlugin) CreateMachine(ctx context.Context, req *driver.CreateMa
 	return response, nil
 }
 
-// InitializeMachine handles VM initialization for GCP VM's. Currently, un-implemented.
-func (ms *MachinePlugin) InitializeMachine(_ context.Context, _ *driver.InitializeMachineRequest) (*driver.InitializeMachineResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "GCP Provider does not yet implement InitializeMachine")
+// InitializeMachine handles VM initialization for GCP VM's
+// OPTIONAL METHOD
+//
+// REQUEST PARAMETERS (driver.InitializeMachineRequest)
+// Machine               Machine            Machine object representing the VM that must be initialized
+// MachineClass          MachineClass       MachineClass backing the machine object
+// Secret                Secret             Secret backing the machineClass object
+//
+// RESPONSE PARAMETERS (driver.InitializeMachineResponse)
+// ProviderID            string             Unique identification of the VM at the cloud provider.
+// NodeName              string             Name of the node-object registered to kubernetes.
+// Addresses             repeated address   Internal and external IPv4 and IPv6 addresses of the VM.
+//
+// It checks whether the VM is RUNNING without waiting for it. If the VM is still provisioning or staging,
+// codes.Uninitialized is returned, so that MCM retries the request. A VM that was stopped or preempted during boot is
+// reported with the code GetMachineStatus reports its status with. The VM is looked up by the ProviderID of the
+// Machine if it is set, and by the name of the Machine otherwise.
+func (ms *MachinePlugin) InitializeMachine(ctx context.Context, req *driver.InitializeMachineRequest) (response *driver.InitializeMachineResponse, err error) {
+	defer instrument.DriverAPIMetricRecorderFn(initializeMachineOperationLabel, &err)()
+
+	// Log messages to track start of request
+	klog.V(2).Infof("Machine initialization request has been received for %q", req.Machine.Name)
+
+	// Check if the MachineClass is for the supported cloud provider
+	if req.MachineClass.Provider != ProviderGCP {
+		err = fmt.Errorf("requested for Provider '%s', we only support '%s'", req.MachineClass.Provider, ProviderGCP)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	providerSpec, err := decodeProviderSpec(req.MachineClass)
+	if err != nil {
+		return nil, prepareErrorf(err, "Initialize machine %q failed on decodeProviderSpec", req.Machine.Name)
+	}
+	if err = validateZone(providerSpec.Zone); err != nil {
+		return nil, prepareErrorf(err, "Initialize machine %q failed on validateZone", req.Machine.Name)
+	}
+	if err = validateSecret(req.Secret, nil); err != nil {
+		return nil, prepareErrorf(err, "Initialize machine %q failed on validateSecret", req.Machine.Name)
+	}
+	providerID, addresses, err := ms.InitializeMachineUtil(ctx, req.Machine.Name, req.Machine.Spec.ProviderID, providerSpec, req.Secret)
+	if err != nil {
+		return nil, prepareErrorf(err, "Initialize machine %q failed", req.Machine.Name)
+	}
+
+	klog.V(2).Infof("VM with Provider-ID: %q initialized for Machine: %q", providerID, req.Machine.Name)
+	return &driver.InitializeMachineResponse{
+		ProviderID: providerID,
+		NodeName:   req.Machine.Name,
+		Addresses:  addresses,
+	}, nil
 }
 
 // DeleteMachine handles a machine deletion request
@@ -146,7 +195,8 @@ func (ms *MachinePlugin) DeleteMachine(ctx context.Context, req *driver.DeleteMa
 	if err = validateZone(providerSpec.Zone); err != nil {
 		return nil, prepareErrorf(err, "Delete machine %q failed on validateZone", req.Machine.Name)
 	}
-	if err = validateSecret(req.Secret); err != nil {
+	// customer-supplied encryption keys are only required to create disks, a missing key must not block the deletion
+	if err = validateSecret(req.Secret, nil); err != nil {
 		return nil, prepareErrorf(err, "Delete machine %q failed on validateSecret", req.Machine.Name)
 	}
 	providerID, err := ms.DeleteMachineUtil(ctx, req.Machine.Name, req.Machine.Spec.ProviderID, providerSpec, req.Secret)
@@ -177,6 +227,12 @@ func (ms *MachinePlugin) DeleteMachine(ctx context.Context, req *driver.DeleteMa
 // NodeName             string              Returns the name of the node-object that the VM register's with Kubernetes.
 //
 //	This could be different from req.MachineName as well
+//
+// A VM that is not RUNNING is reported with an error matching its status: codes.Uninitialized while it boots or is
+// repaired, codes.Unavailable while it is being stopped or suspended, codes.FailedPrecondition once it is stopped or
+// suspended and codes.Aborted if it was terminated or preempted. In the creation flow, MCM keeps such a Machine in
+// CrashLoopBackOff and replaces it once the creation timeout has passed. A Machine that is being deleted is only
+// told whether its VM exists, as MCM marks it Failed and retries the deletion forever for any of these codes.
 func (ms *MachinePlugin) GetMachineStatus(ctx context.Context, req *driver.GetMachineStatusRequest) (response *driver.GetMachineStatusResponse, err error) {
 	defer instrument.DriverAPIMetricRecorderFn(getMachineStatusOperationLabel, &err)()
 
@@ -196,12 +252,23 @@ func (ms *MachinePlugin) GetMachineStatus(ctx context.Context, req *driver.GetMa
 	if err = validateZone(providerSpec.Zone); err != nil {
 		return nil, prepareErrorf(err, "Machine status %q failed on validateZone", req.Machine.Name)
 	}
-	if err = validateSecret(req.Secret); err != nil {
+	if err = validateSecret(req.Secret, nil); err != nil {
 		return nil, prepareErrorf(err, "Machine status %q failed on validateSecret", req.Machine.Name)
 	}
 	providerID, err := ms.GetMachineStatusUtil(ctx, req.Machine.Name, req.Machine.Spec.ProviderID, providerSpec, req.Secret)
 	if err != nil {
-		return nil, prepareErrorf(err, "Machine status %q failed", req.Machine.Name)
+		if providerID == "" {
+			return nil, prepareErrorf(err, "Machine status %q failed", req.Machine.Name)
+		}
+		if _, notRunning := err.(*errors2.MachineNotRunningError); !notRunning || req.Machine.DeletionTimestamp == nil {
+			// the VM exists, but is not running. MCM reads the response of VMs reported as uninitialized
+			return &driver.GetMachineStatusResponse{
+				ProviderID: providerID,
+				NodeName:   req.Machine.Name,
+			}, prepareErrorf(err, "Machine status %q failed", req.Machine.Name)
+		}
+		// e.g. a Spot VM reclaimed before its node registered must still be deletable
+		klog.V(2).Infof("Machine status: ignoring status of VM %q for Machine %q being deleted: %v", providerID, req.Machine.Name, err)
 	}
 
 	response = &driver.GetMachineStatusResponse{
@@ -246,7 +313,7 @@ func (ms *MachinePlugin) ListMachines(ctx context.Context, req *driver.ListMachi
 	if err = validateZone(providerSpec.Zone); err != nil {
 		return nil, prepareErrorf(err, "List machines failed on validateZone")
 	}
-	if err = validateSecret(req.Secret); err != nil {
+	if err = validateSecret(req.Secret, nil); err != nil {
 		return nil, prepareErrorf(err, "List machines failed on validateSecret")
 	}
 	machineList, err := ms.ListMachinesUtil(ctx, providerSpec, req.Secret)
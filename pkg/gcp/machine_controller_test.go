@@ -7,16 +7,24 @@ package gcp
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"time"
 
 	v1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/metrics"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/api/compute/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	api "github.com/gardener/machine-controller-manager-provider-gcp/pkg/api/v1alpha1"
+	errors2 "github.com/gardener/machine-controller-manager-provider-gcp/pkg/gcp/errors"
 	fake "github.com/gardener/machine-controller-manager-provider-gcp/pkg/gcp/fake"
 )
 
@@ -47,6 +55,8 @@ const (
 	DeleteFailAtInvalidZoneListCall string = "machine codes error: code = [Internal] message = [Delete machine \"dummy-machine\" failed: googleapi: got HTTP response code 400 with body: Invalid list zone\n]"
 	// ListFailAtInvalidZoneListCall is the  error returned when a list call should fail with an invalid zone is sent in the LIST call -- this is used to simulate server error
 	ListFailAtInvalidZoneListCall string = "machine codes error: code = [Internal] message = [List machines failed: googleapi: got HTTP response code 400 with body: Invalid list zone\n]"
+	// ListFailAtMissingClusterTags is the error returned when the machines of a class without cluster and role tags are listed
+	ListFailAtMissingClusterTags string = "machine codes error: code = [Internal] message = [List machines failed: provider spec must have exactly one network tag with prefix \"kubernetes-io-cluster-\" and one with prefix \"kubernetes-io-role-\" to identify the machines of the cluster]"
 	// FailAtMethodNotImplemented is the error returned for methods which are not yet implemented
 	FailAtMethodNotImplemented string = "rpc error: code = Unimplemented desc = "
 	// FailAtSpecValidation fails at spec validation
@@ -58,6 +68,63 @@ const (
 	// FailAtSpecValidationInvalidKmsServiceAccount if kmsKeyServiceAccount invalid
 	FailAtSpecValidationInvalidKmsServiceAccount string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.disks[0].kmsKeyServiceAccount: Required value: kmsKeyServiceAccount should either be explicitly specified without spaces or left un-specified to default to the Compute Service Agent]]]"
 
+	// FailAtSpecValidationSpotAutomaticRestart if automaticRestart is enabled for a Spot VM
+	FailAtSpecValidationSpotAutomaticRestart string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.scheduling.automaticRestart: Forbidden: automaticRestart must be false for Spot VMs]]]"
+	// FailAtSpecValidationConfidentialUnsupportedSeries if the confidential instance type is not supported by the machine series
+	FailAtSpecValidationConfidentialUnsupportedSeries string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.confidentialInstanceConfig.confidentialInstanceType: Forbidden: TDX is not supported by machine type \"n2d-standard-2\", supported machine series are c3]]]"
+	// FailAtSpecValidationSpecificReservationNoValues if a SPECIFIC_RESERVATION affinity has no reservations
+	FailAtSpecValidationSpecificReservationNoValues string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.reservationAffinity.values: Required value: at least one reservation is required for SPECIFIC_RESERVATION]]]"
+	// FailAtSpecValidationNodeAffinityInvalidOperator if a node affinity uses an unsupported operator
+	FailAtSpecValidationNodeAffinityInvalidOperator string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.scheduling.nodeAffinities[0].operator: Unsupported value: \"EQUALS\": supported values: \"IN\", \"NOT_IN\"]]]"
+	// FailAtSpecValidationTier1WithoutGVNIC if TIER_1 networking is requested without gVNIC
+	FailAtSpecValidationTier1WithoutGVNIC string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.networkInterfaces[0].nicType: Forbidden: TIER_1 egress bandwidth requires nicType GVNIC]]]"
+	// FailAtSpecValidationInvalidSubnetworkURL if the subnetwork is neither a name nor a valid subnetwork URL
+	FailAtSpecValidationInvalidSubnetworkURL string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.networkInterfaces[0].subnetwork: Invalid value: \"regions/europe-dummy/dummyShoot\": must be a subnetwork name or a URL of the form projects/<project>/regions/<region>/subnetworks/<name>]]]"
+	// FailAtSpecValidationNatIPWithExternalIPDisabled if a static external IP is requested while the external IP is disabled
+	FailAtSpecValidationNatIPWithExternalIPDisabled string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.networkInterfaces[0].natIP: Forbidden: natIP cannot be set when disableExternalIP is true]]]"
+	// FailAtSpecValidationIPv4AliasIPRangeTooLarge if an IPv6 prefix length is used for an alias IP range of an IPv4 interface
+	FailAtSpecValidationIPv4AliasIPRangeTooLarge string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.networkInterfaces[0].aliasIPRanges[0].ipCidrRange: Invalid value: \"/96\": CIDR mask size must be between 0 and 32]]]"
+	// FailAtSpecValidationIPv6OnlyLegacyAliasIPRange if the single alias IP range fields are used on an IPv6-only interface
+	FailAtSpecValidationIPv6OnlyLegacyAliasIPRange string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.networkInterfaces[0].ipCidrRange: Forbidden: ipCidrRange cannot be set on an IPV6_ONLY interface, use aliasIPRanges spec.networkInterfaces[0].subnetworkRangeName: Forbidden: subnetworkRangeName cannot be set on an IPV6_ONLY interface, use aliasIPRanges]]]"
+	// FailAtSpecValidationIPv6OnlyNetworkTier if the IPv4 access config fields are used on an IPv6-only interface
+	FailAtSpecValidationIPv6OnlyNetworkTier string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.networkInterfaces[0].networkTier: Forbidden: networkTier cannot be set on an IPV6_ONLY interface spec.networkInterfaces[0].publicPtrDomainName: Forbidden: publicPtrDomainName cannot be set on an IPV6_ONLY interface]]]"
+	// FailAtSpecValidationDiskConflictingSources if a disk is seeded from several sources
+	FailAtSpecValidationDiskConflictingSources string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.disks[1]: Forbidden: only one of image, sourceSnapshot and sourceDisk can be set]]]"
+	// FailAtSpecValidationInvalidInstanceTemplate if the instance template is neither a name nor a global or regional template URL
+	FailAtSpecValidationInvalidInstanceTemplate string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.sourceInstanceTemplate: Invalid value: \"projects/dummy/zones/europe-dummy/instanceTemplates/dummy-template\": must be an instance template name or a URL of the form projects/<project>/global/instanceTemplates/<name> or projects/<project>/regions/<region>/instanceTemplates/<name>]]]"
+	// FailAtSpecValidationCustomMachineTypeTooMuchMemory if a custom machine type exceeds the memory per vCPU of its series
+	FailAtSpecValidationCustomMachineTypeTooMuchMemory string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.customMachineType.memoryMiB: Invalid value: 131072: must be at most 8192 MiB per vCPU for series n2, unless extendedMemory is enabled]]]"
+	// FailAtSpecValidationVisibleCoreCountExceedsCores if more cores are made visible than a custom machine type has
+	FailAtSpecValidationVisibleCoreCountExceedsCores string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.advancedMachineFeatures.visibleCoreCount: Invalid value: 5: must not exceed the 4 cores of the custom machine type with 8 vCPUs and 2 threads per core]]]"
+	// FailAtSpecValidationInvalidThreadsPerCore if an unsupported number of threads per core is requested
+	FailAtSpecValidationInvalidThreadsPerCore string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.advancedMachineFeatures.threadsPerCore: Invalid value: 4: must be either 1 or 2]]]"
+	// FailAtSpecValidationInvalidResourceManagerTagValue if a Resource Manager tag value is not given by its ID
+	FailAtSpecValidationInvalidResourceManagerTagValue string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.resourceManagerTags[tagKeys/281475012345678]: Invalid value: \"production\": value must be of the form tagValues/{tag_value_id}]]]"
+	// FailAtSpecValidationInvalidLabels if labels are not valid GCE labels and sanitization is disabled
+	FailAtSpecValidationInvalidLabels string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.labels: Invalid value: \"app.kubernetes.io/name\": key must start with a lowercase letter and consist of at most 63 lowercase letters, digits, underscores and dashes spec.labels[app.kubernetes.io/name]: Invalid value: \"Worker\": value must consist of at most 63 lowercase letters, digits, underscores and dashes]]]"
+	// FailAtSpecValidationHostnameSingleLabel if the hostname template does not render a FQDN
+	FailAtSpecValidationHostnameSingleLabel string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.hostname: Invalid value: \"{{.MachineName}}\": hostname must consist of at least two labels]]]"
+	// FailAtStagingMachine if the status of a booting instance is requested
+	FailAtStagingMachine string = "machine codes error: code = [Uninitialized] message = [Machine status \"dummy-machine\" failed: instance \"dummy-machine\" is in status STAGING, it is still booting]"
+	// FailAtTerminatedMachine if the status of a terminated instance is requested
+	FailAtTerminatedMachine string = "machine codes error: code = [Aborted] message = [Machine status \"dummy-machine\" failed: instance \"dummy-machine\" is in status TERMINATED, it was stopped or preempted and has to be replaced]"
+	// FailAtMachineOfOtherCluster if the instance exists but belongs to another cluster
+	FailAtMachineOfOtherCluster string = "machine codes error: code = [NotFound] message = [machine name=dummy-machine, uuid= not found]"
+	// InitializeFailAtStagingMachine if the instance to initialize is still booting
+	InitializeFailAtStagingMachine string = "machine codes error: code = [Uninitialized] message = [Initialize machine \"dummy-machine\" failed: instance \"dummy-machine\" is in status STAGING, it is still booting]"
+	// InitializeFailAtTerminatedMachine if the instance to initialize was terminated during boot
+	InitializeFailAtTerminatedMachine string = "machine codes error: code = [Aborted] message = [Initialize machine \"dummy-machine\" failed: instance \"dummy-machine\" is in status TERMINATED, it was stopped or preempted and has to be replaced]"
+	// FailAtSecretValidationMissingCustomerSuppliedKey if the customer-supplied keys referenced by a disk are missing in the secret
+	FailAtSecretValidationMissingCustomerSuppliedKey string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateSecret: machine codes error: code = [Internal] message = [error while validating Secret [secret diskEncryptionKey is required field for the customer-supplied encryption key secret imageEncryptionKey is required field for the customer-supplied encryption key]]]"
+	// FailAtSpecValidationCustomerSuppliedKeyWithKmsKeyName if a disk is encrypted with both a cloud kms and a customer-supplied key
+	FailAtSpecValidationCustomerSuppliedKeyWithKmsKeyName string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.disks[0].customerSuppliedKey: Forbidden: customerSuppliedKey cannot be combined with kmsKeyName]]]"
+	// FailAtSpecValidationProvisionedIopsNotSupported if IOPS are provisioned for a disk type that does not accept them
+	FailAtSpecValidationProvisionedIopsNotSupported string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.disks[1].provisionedIops: Forbidden: not supported by disk type pd-balanced]]]"
+	// FailAtSpecValidationProvisionedIopsOutOfRange if the provisioned IOPS of a Hyperdisk exceed the limit for its size
+	FailAtSpecValidationProvisionedIopsOutOfRange string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.disks[1].provisionedIops: Invalid value: 6000: must be between 3000 and 5000 for disk type hyperdisk-balanced with 10 GiB]]]"
+	// FailAtSpecValidationAmbiguousClusterTags if the machines of the cluster cannot be identified by the tags
+	FailAtSpecValidationAmbiguousClusterTags string = "machine codes error: code = [Internal] message = [Create machine \"dummy-machine\" failed on validateProviderSpec: machine codes error: code = [Internal] message = [error while validating ProviderSpec [spec.tags[1]: Invalid value: \"kubernetes-io-cluster-dummy-machine\": only one tag with prefix \"kubernetes-io-cluster-\" is allowed]]]"
+
 	UnsupportedProviderError string = "machine codes error: code = [InvalidArgument] message = [requested for Provider 'aws', we only support 'GCP']"
 )
 
@@ -68,6 +135,13 @@ var _ = BeforeSuite(func() {
 	// Start a mock server to listen to mock client requests
 	// This is rquired as compute sdk doesn't offer any interface so the mocking is done via a mock http client pass to the compute service
 	go fake.NewMockServer()
+	Eventually(func() error {
+		conn, err := net.Dial("tcp", "127.0.0.1:6666")
+		if err == nil {
+			_ = conn.Close()
+		}
+		return err
+	}).Should(Succeed())
 	mockPluginSPIImpl = &fake.PluginSPIImpl{Client: &http.Client{}}
 	ms = NewGCPPlugin(mockPluginSPIImpl)
 })
@@ -82,6 +156,53 @@ var _ = Describe("#MachineController", func() {
 	gcpProviderSpecInvalidKmsKeyServiceAccount := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\", \"encryption\": { \"kmsKeyName\": \"bingo\", \"kmsKeyServiceAccount\": \"  \"}, \"labels\":{\"name\":\"test-mc-gcp\"}}], \"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"invalid list\"}")
 	gcpProviderSpecNoKmsKeyName := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\", \"encryption\": { \"kmsKeyServiceAccount\": \"tringo\" }, \"labels\":{\"name\":\"test-mc-gcp\"}}], \"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"invalid list\"}")
 	gcpProviderSpecAdvancedMachineFeatures := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"advancedMachineFeatures\":{\"enableNestedVirtualization\":true}}")
+	gcpProviderSpecSpot := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":false,\"onHostMaintenance\":\"TERMINATE\",\"preemptible\":false,\"provisioningModel\":\"SPOT\",\"instanceTerminationAction\":\"DELETE\",\"maxRunDuration\":\"4h\"},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecSpotAutomaticRestart := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"TERMINATE\",\"preemptible\":false,\"provisioningModel\":\"SPOT\",\"instanceTerminationAction\":\"DELETE\",\"maxRunDuration\":\"4h\"},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecConfidential := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n2d-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"TERMINATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"confidentialInstanceConfig\":{\"confidentialInstanceType\":\"SEV_SNP\"}}")
+	gcpProviderSpecConfidentialUnsupportedSeries := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n2d-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"TERMINATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"confidentialInstanceConfig\":{\"confidentialInstanceType\":\"TDX\"}}")
+	gcpProviderSpecSpecificReservation := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"reservationAffinity\":{\"consumeReservationType\":\"SPECIFIC_RESERVATION\",\"values\":[\"projects/dummy-host/reservations/dummy-reservation\"]}}")
+	gcpProviderSpecSpecificReservationNoValues := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"reservationAffinity\":{\"consumeReservationType\":\"SPECIFIC_RESERVATION\"}}")
+	gcpProviderSpecSoleTenant := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false,\"minNodeCpus\":4,\"nodeAffinities\":[{\"key\":\"compute.googleapis.com/node-group-name\",\"operator\":\"IN\",\"values\":[\"dummy-node-group\"]}]},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecSoleTenantInvalidOperator := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false,\"minNodeCpus\":4,\"nodeAffinities\":[{\"key\":\"compute.googleapis.com/node-group-name\",\"operator\":\"EQUALS\",\"values\":[\"dummy-node-group\"]}]},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecResourcePolicies := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"resourcePolicies\":[\"dummy-placement-policy\",\"projects/dummy-project/regions/europe-dummy/resourcePolicies/dummy-schedule-policy\"]}")
+	gcpProviderSpecTier1 := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\",\"nicType\":\"GVNIC\",\"queueCount\":8}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"networkPerformanceConfig\":{\"totalEgressBandwidthTier\":\"TIER_1\"}}")
+	gcpProviderSpecTier1VirtioNet := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\",\"nicType\":\"VIRTIO_NET\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"networkPerformanceConfig\":{\"totalEgressBandwidthTier\":\"TIER_1\"}}")
+	gcpProviderSpecSharedVPCHostProject := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\",\"hostProject\":\"dummy-host-project\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecSharedVPCSelfLinks := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"https://www.googleapis.com/compute/v1/projects/dummy-host-project/global/networks/dummyShoot\",\"subnetwork\":\"projects/dummy-host-project/regions/europe-dummy/subnetworks/dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecSharedVPCInvalidSubnetwork := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"https://www.googleapis.com/compute/v1/projects/dummy-host-project/global/networks/dummyShoot\",\"subnetwork\":\"regions/europe-dummy/dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecStaticIPs := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\",\"networkIP\":\"10.250.0.10\",\"natIP\":\"dummy-address\",\"networkTier\":\"STANDARD\",\"publicPtrDomainName\":\"egress.example.com.\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecStaticIPsExternalIPDisabled := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\",\"disableExternalIP\":true,\"natIP\":\"203.0.113.10\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecAliasIPRanges := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\",\"aliasIPRanges\":[{\"ipCidrRange\":\"/24\",\"subnetworkRangeName\":\"pods\"},{\"ipCidrRange\":\"/28\",\"subnetworkRangeName\":\"cni\"}]}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecIPv6Only := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\",\"stackType\":\"IPV6_ONLY\",\"ipv6accessType\":\"EXTERNAL\",\"aliasIPRanges\":[{\"ipCidrRange\":\"/96\"}]}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecIPv4AliasIPRangeTooLarge := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\",\"aliasIPRanges\":[{\"ipCidrRange\":\"/96\"}]}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecIPv6OnlyLegacyAliasIPRange := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\",\"stackType\":\"IPV6_ONLY\",\"ipv6accessType\":\"EXTERNAL\",\"ipCidrRange\":\"/96\",\"subnetworkRangeName\":\"ipv6-range\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecIPv6OnlyNetworkTier := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\",\"stackType\":\"IPV6_ONLY\",\"ipv6accessType\":\"EXTERNAL\",\"networkTier\":\"STANDARD\",\"publicPtrDomainName\":\"dummy.example.com\",\"aliasIPRanges\":[{\"ipCidrRange\":\"/96\"}]}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecDiskSources := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}},{\"source\":\"image-cache\",\"mode\":\"READ_ONLY\"},{\"sizeGb\":100,\"type\":\"pd-balanced\",\"sourceSnapshot\":\"image-cache-snapshot\"},{\"sizeGb\":100,\"type\":\"pd-balanced\",\"sourceDisk\":\"image-cache\"}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecDiskSourcesFailedOperation := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}},{\"source\":\"image-cache\",\"mode\":\"READ_ONLY\"},{\"sizeGb\":100,\"type\":\"pd-balanced\",\"sourceSnapshot\":\"image-cache-snapshot\"},{\"sizeGb\":100,\"type\":\"pd-balanced\",\"sourceDisk\":\"image-cache\"}],\"labels\":{\"name\":\"test-mc-gcp\",\"mock-failed-operation\":\"true\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecDiskConflictingSources := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}},{\"sizeGb\":100,\"type\":\"pd-balanced\",\"sourceSnapshot\":\"image-cache-snapshot\",\"sourceDisk\":\"image-cache\"}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecInstanceTemplate := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"labels\":{\"name\":\"test-mc-gcp\"},\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"sourceInstanceTemplate\":\"dummy-template\"}")
+	gcpProviderSpecInvalidInstanceTemplate := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"labels\":{\"name\":\"test-mc-gcp\"},\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"sourceInstanceTemplate\":\"projects/dummy/zones/europe-dummy/instanceTemplates/dummy-template\"}")
+	gcpProviderSpecCustomMachineType := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"customMachineType\":{\"series\":\"n2\",\"cpus\":8,\"memoryMiB\":32768},\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecCustomMachineTypeTooMuchMemory := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"customMachineType\":{\"series\":\"n2\",\"cpus\":8,\"memoryMiB\":131072},\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecCustomMachineTypeTooManyVisibleCores := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"customMachineType\":{\"series\":\"n2\",\"cpus\":8,\"memoryMiB\":32768},\"advancedMachineFeatures\":{\"visibleCoreCount\":5},\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecAdvancedMachineFeaturesFull := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"advancedMachineFeatures\":{\"threadsPerCore\":1,\"visibleCoreCount\":1,\"turboMode\":\"ALL_CORE_MAX\",\"enableUefiNetworking\":true,\"performanceMonitoringUnit\":\"STANDARD\"}}")
+	gcpProviderSpecAdvancedMachineFeaturesInvalidThreads := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"advancedMachineFeatures\":{\"threadsPerCore\":4}}")
+	gcpProviderSpecResourceManagerTags := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"},\"resourceManagerTags\":{\"tagKeys/281475012345678\":\"tagValues/281475087654321\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"resourceManagerTags\":{\"tagKeys/281475012345678\":\"tagValues/281475087654321\"}}")
+	gcpProviderSpecInvalidResourceManagerTags := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"resourceManagerTags\":{\"tagKeys/281475012345678\":\"production\"}}")
+	gcpProviderSpecInvalidLabels := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\",\"app.kubernetes.io/name\":\"Worker\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecSanitizedLabels := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\",\"app.kubernetes.io/name\":\"Worker\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"Kubernetes_IO_Role\",\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"sanitizeLabelsAndTags\":true}")
+	gcpProviderSpecHostname := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"hostname\":\"{{.MachineName}}.nodes.example.com\"}")
+	gcpProviderSpecHostnameSingleLabel := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\",\"hostname\":\"{{.MachineName}}\"}")
+	gcpProviderSpecOtherCluster := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-other-cluster\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecStaging := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\",\"mock-status\":\"staging\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecTerminated := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\",\"mock-status\":\"terminated\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecCustomerSuppliedKey := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"},\"encryption\":{\"customerSuppliedKey\":{\"rawKey\":\"diskEncryptionKey\"},\"sourceImageKey\":{\"rsaEncryptedKey\":\"imageEncryptionKey\"}}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecSourceImageKey := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"},\"encryption\":{\"sourceImageKey\":{\"rsaEncryptedKey\":\"imageEncryptionKey\"}}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecCustomerSuppliedKeyWithKmsKeyName := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"},\"encryption\":{\"kmsKeyName\":\"projects/projectId/locations/region/keyRings/keyRingName/cryptoKeys/keyName\",\"customerSuppliedKey\":{\"rawKey\":\"diskEncryptionKey\"}}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecRegionalDisk := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}},{\"sizeGb\":100,\"type\":\"pd-balanced\",\"replicaZones\":[\"europe-dummy-a\",\"europe-dummy-b\"]}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy-a\"}")
+	gcpProviderSpecProvisionedIopsNotSupported := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}},{\"sizeGb\":100,\"type\":\"pd-balanced\",\"provisionedIops\":3000}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecProvisionedIopsOutOfRange := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}},{\"sizeGb\":10,\"type\":\"hyperdisk-balanced\",\"provisionedIops\":6000}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy-machine\",\"kubernetes-io-role-mcm\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
+	gcpProviderSpecAmbiguousClusterTags := []byte("{\"canIpForward\":true,\"deletionProtection\":false,\"description\":\"Machine created to test out-of-tree gcp mcm driver.\",\"disks\":[{\"autoDelete\":true,\"boot\":true,\"sizeGb\":50,\"type\":\"pd-standard\",\"image\":\"projects/coreos-cloud/global/images/coreos-stable-2135-6-0-v20190801\",\"labels\":{\"name\":\"test-mc-gcp\"}}],\"labels\":{\"name\":\"test-mc-gcp\"},\"machineType\":\"n1-standard-2\",\"metadata\":[{\"key\":\"gcp\",\"value\":\"my-value\"}],\"networkInterfaces\":[{\"network\":\"dummyShoot\",\"subnetwork\":\"dummyShoot\"}],\"scheduling\":{\"automaticRestart\":true,\"onHostMaintenance\":\"MIGRATE\",\"preemptible\":false},\"secretRef\":{\"name\":\"dummySecret\",\"namespace\":\"dummy\"},\"serviceAccounts\":[{\"email\":\"mcmDummy@dummy.com\",\"scopes\":[\"https://www.googleapis.com/auth/compute\"]}],\"tags\":[\"kubernetes-io-cluster-dummy\",\"kubernetes-io-cluster-dummy-machine\",\"dummy-machine\"],\"region\":\"europe-dummy\",\"zone\":\"europe-dummy\"}")
 
 	gcpPVSpecIntree := &corev1.PersistentVolumeSpec{
 		PersistentVolumeSource: corev1.PersistentVolumeSource{
@@ -121,6 +242,12 @@ var _ = Describe("#MachineController", func() {
 		"userData":           []byte("dummy-data"),
 		"serviceAccountJSON": []byte("{\"type\":\"service_account\",\"project_id\":\"sap-se-gcp-scp-k8s-dev\"}"),
 	}
+	gcpProviderSecretWithCustomerSuppliedKeys := map[string][]byte{
+		"userData":           []byte("dummy-data"),
+		"serviceAccountJSON": []byte("{\"type\":\"service_account\",\"project_id\":\"sap-se-gcp-scp-k8s-dev\"}"),
+		"diskEncryptionKey":  []byte("SGVsbG8gZnJvbSBHb29nbGUgQ2xvdWQgUGxhdGZvcm0="),
+		"imageEncryptionKey": []byte("ieCx/NcW06PcT7Ep1X6LUTc/hLvUDYyzSZPPVCVPTVEohpeHASqC8uw5TzyO9U+Fka9JFHz0mBibXUInrC/jEk014kCK/NPjYgEMOyssZ4ZINPKxlUh2zn1bV+MCaTICrdmuSBTWlUUiFoDD6PYznLwh8ZNdaheCeZ8ewEXgFQ8V+sDroLaN3Xs3MDTXQEMMoNUXMCZEIpg9Vtp9x2oeQ5lAbtt7bYAAHf5l+gJWw3sUfs0/Glw5fpdjT8Uggrr+RMZezGrltJEF293rvTIjWOEB3z5OHyHwQkvdrPDFcTqsLfh+8Hr8g+mf+7zVPEC8nEbqpdl3GPv3A7AwpFp7MA=="),
+	}
 	gcpProviderSecretWithCredentialsConfig := map[string][]byte{
 		"userData":          []byte("dummy-data"),
 		"credentialsConfig": []byte("{\"type\":\"service_account\",\"project_id\":\"sap-se-gcp-scp-k8s-dev\"}"),
@@ -138,6 +265,7 @@ var _ = Describe("#MachineController", func() {
 	var _ = BeforeEach(func() {
 		// Reinitialise instances
 		fake.Instances = nil
+		fake.Disks = nil
 	})
 
 	Describe("##CreateMachine", func() {
@@ -148,6 +276,8 @@ var _ = Describe("#MachineController", func() {
 			machineResponse   *driver.CreateMachineResponse
 			errToHaveOccurred bool
 			errMessage        string
+			// instance asserts the fields rendered into the inserted instance
+			instance func(instance *compute.Instance)
 		}
 		type data struct {
 			action action
@@ -165,6 +295,10 @@ var _ = Describe("#MachineController", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(data.expect.machineResponse.ProviderID).To(Equal(response.ProviderID))
 					Expect(data.expect.machineResponse.NodeName).To(Equal(response.NodeName))
+					if data.expect.instance != nil {
+						Expect(fake.Instances).To(HaveLen(1))
+						data.expect.instance(fake.Instances[0])
+					}
 				}
 			},
 
@@ -182,6 +316,14 @@ var _ = Describe("#MachineController", func() {
 						NodeName:   "dummy-machine",
 					},
 					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.MachineType).To(Equal("zones/europe-dummy/machineTypes/n1-standard-2"))
+						Expect(instance.Tags.Items).To(Equal([]string{"kubernetes-io-cluster-dummy-machine", "kubernetes-io-role-mcm", "dummy-machine"}))
+						Expect(instance.Disks).To(HaveLen(1))
+						Expect(instance.Disks[0].InitializeParams.DiskType).To(Equal("zones/europe-dummy/diskTypes/pd-standard"))
+						Expect(instance.NetworkInterfaces[0].Network).To(Equal("projects/sap-se-gcp-scp-k8s-dev/global/networks/dummyShoot"))
+						Expect(instance.NetworkInterfaces[0].Subnetwork).To(Equal("regions/europe-dummy/subnetworks/dummyShoot"))
+					},
 				},
 			}),
 			Entry("Create a simple machine from secret with credentialsConfig", &data{
@@ -214,13 +356,16 @@ var _ = Describe("#MachineController", func() {
 						NodeName:   "dummy-machine",
 					},
 					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.AdvancedMachineFeatures).To(Equal(&compute.AdvancedMachineFeatures{EnableNestedVirtualization: true}))
+					},
 				},
 			}),
-			Entry("Machine creation with disk type as PD balanced", &data{
+			Entry("Create a spot machine with max run duration", &data{
 				action: action{
 					machineRequest: &driver.CreateMachineRequest{
 						Machine:      newMachine("dummy-machine"),
-						MachineClass: newGCPMachineClass(gcpProviderSpecPDBalanced, ""),
+						MachineClass: newGCPMachineClass(gcpProviderSpecSpot, ""),
 						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
@@ -230,325 +375,1223 @@ var _ = Describe("#MachineController", func() {
 						NodeName:   "dummy-machine",
 					},
 					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.Scheduling.ProvisioningModel).To(Equal("SPOT"))
+						Expect(instance.Scheduling.InstanceTerminationAction).To(Equal("DELETE"))
+						Expect(*instance.Scheduling.AutomaticRestart).To(BeFalse())
+						Expect(instance.Scheduling.MaxRunDuration).To(Equal(&compute.Duration{Seconds: 4 * 60 * 60}))
+					},
 				},
 			}),
-			Entry("Create a simple machine with unsupported provider in MachineClass", &data{
+			Entry("Create a spot machine with automatic restart enabled", &data{
 				action: action{
 					machineRequest: &driver.CreateMachineRequest{
 						Machine:      newMachine("dummy-machine"),
-						MachineClass: newGCPMachineClass(gcpProviderSpec, "aws"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecSpotAutomaticRestart, ""),
 						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
 					errToHaveOccurred: true,
-					errMessage:        UnsupportedProviderError,
+					errMessage:        FailAtSpecValidationSpotAutomaticRestart,
 				},
 			}),
-			Entry("With zone missing", &data{
+			Entry("Create a confidential machine", &data{
 				action: action{
 					machineRequest: &driver.CreateMachineRequest{
 						Machine:      newMachine("dummy-machine"),
-						MachineClass: newGCPMachineClass(gcpProviderSpecValidationErr, ""),
+						MachineClass: newGCPMachineClass(gcpProviderSpecConfidential, ""),
 						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
-					errToHaveOccurred: true,
-					errMessage:        FailAtSpecValidation,
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.ConfidentialInstanceConfig).To(Equal(&compute.ConfidentialInstanceConfig{EnableConfidentialCompute: true, ConfidentialInstanceType: "SEV_SNP"}))
+						Expect(instance.Scheduling.OnHostMaintenance).To(Equal("TERMINATE"))
+					},
 				},
 			}),
-			Entry("With no provider spec", &data{
+			Entry("Create a confidential machine with a type unsupported by the machine series", &data{
 				action: action{
 					machineRequest: &driver.CreateMachineRequest{
 						Machine:      newMachine("dummy-machine"),
-						MachineClass: newGCPMachineClass([]byte(""), ""),
+						MachineClass: newGCPMachineClass(gcpProviderSpecConfidentialUnsupportedSeries, ""),
 						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
 					errToHaveOccurred: true,
-					errMessage:        CreateFailAtJSONUnmarshalling,
+					errMessage:        FailAtSpecValidationConfidentialUnsupportedSeries,
 				},
 			}),
-
-			Entry("With no secrets", &data{
+			Entry("Create a machine consuming a specific shared reservation", &data{
 				action: action{
 					machineRequest: &driver.CreateMachineRequest{
 						Machine:      newMachine("dummy-machine"),
-						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
-						Secret:       newSecret(make(map[string][]byte)),
+						MachineClass: newGCPMachineClass(gcpProviderSpecSpecificReservation, ""),
+						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
-					errToHaveOccurred: true,
-					errMessage:        FailAtNoSecretsPassed,
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.ReservationAffinity).To(Equal(&compute.ReservationAffinity{
+							ConsumeReservationType: "SPECIFIC_RESERVATION",
+							Key:                    "googleapis.com/reservation-name",
+							Values:                 []string{"projects/dummy-host/reservations/dummy-reservation"},
+						}))
+					},
 				},
 			}),
-			Entry("With secrets missing user data", &data{
+			Entry("Create a machine consuming a specific reservation without values", &data{
 				action: action{
 					machineRequest: &driver.CreateMachineRequest{
 						Machine:      newMachine("dummy-machine"),
-						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
-						Secret:       newSecret(gcpProviderSecretWithMisssingUserData),
+						MachineClass: newGCPMachineClass(gcpProviderSpecSpecificReservationNoValues, ""),
+						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
 					errToHaveOccurred: true,
-					errMessage:        FailAtSecretsWithNoUserData,
+					errMessage:        FailAtSpecValidationSpecificReservationNoValues,
 				},
 			}),
-			Entry("With secrets having invalid projectID value", &data{
+			Entry("Create a machine on a sole-tenant node group", &data{
 				action: action{
 					machineRequest: &driver.CreateMachineRequest{
 						Machine:      newMachine("dummy-machine"),
-						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
-						Secret:       newSecret(gcpProviderSecretWithoutProjectID),
+						MachineClass: newGCPMachineClass(gcpProviderSpecSoleTenant, ""),
+						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
-					errToHaveOccurred: true,
-					errMessage:        FailAtInvalidProjectID,
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.Scheduling.MinNodeCpus).To(Equal(int64(4)))
+						Expect(instance.Scheduling.NodeAffinities).To(Equal([]*compute.SchedulingNodeAffinity{
+							{Key: "compute.googleapis.com/node-group-name", Operator: "IN", Values: []string{"dummy-node-group"}},
+						}))
+					},
 				},
 			}),
-			Entry("With Post for invalid zone", &data{
+			Entry("Create a machine with an unsupported node affinity operator", &data{
 				action: action{
 					machineRequest: &driver.CreateMachineRequest{
 						Machine:      newMachine("dummy-machine"),
-						MachineClass: newGCPMachineClass(gcpProviderSpecInvalidPostZone, ""),
+						MachineClass: newGCPMachineClass(gcpProviderSpecSoleTenantInvalidOperator, ""),
 						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
 					errToHaveOccurred: true,
-					errMessage:        FailAtInvalidZonePostCall,
+					errMessage:        FailAtSpecValidationNodeAffinityInvalidOperator,
 				},
 			}),
-			Entry("With invalid list zone", &data{
+			Entry("Create a machine with resource policies", &data{
 				action: action{
 					machineRequest: &driver.CreateMachineRequest{
 						Machine:      newMachine("dummy-machine"),
-						MachineClass: newGCPMachineClass(gcpProviderSpecInvalidListZone, ""),
+						MachineClass: newGCPMachineClass(gcpProviderSpecResourcePolicies, ""),
 						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
-					errToHaveOccurred: true,
-					errMessage:        CreateFailAtInvalidZoneListCall,
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.ResourcePolicies).To(Equal([]string{
+							"projects/sap-se-gcp-scp-k8s-dev/regions/europe-dummy/resourcePolicies/dummy-placement-policy",
+							"projects/dummy-project/regions/europe-dummy/resourcePolicies/dummy-schedule-policy",
+						}))
+					},
 				},
 			}),
-			Entry("With disk.Encryption.KmsKeyName specified and invalid disk.Encryption.KmsKeyServiceAccount", &data{
+			Entry("Create a machine with TIER_1 networking and gVNIC", &data{
 				action: action{
 					machineRequest: &driver.CreateMachineRequest{
 						Machine:      newMachine("dummy-machine"),
-						MachineClass: newGCPMachineClass(gcpProviderSpecInvalidKmsKeyServiceAccount, ""),
+						MachineClass: newGCPMachineClass(gcpProviderSpecTier1, ""),
 						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
-					errToHaveOccurred: true,
-					errMessage:        FailAtSpecValidationInvalidKmsServiceAccount,
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.NetworkPerformanceConfig).To(Equal(&compute.NetworkPerformanceConfig{TotalEgressBandwidthTier: "TIER_1"}))
+						Expect(instance.NetworkInterfaces[0].NicType).To(Equal("GVNIC"))
+						Expect(instance.NetworkInterfaces[0].QueueCount).To(Equal(int64(8)))
+					},
 				},
 			}),
-			Entry("With disk.Encryption.KmsKeyServiceAccount specified but no disk.Encryption.KmsKeyName", &data{
+			Entry("Create a machine with TIER_1 networking without gVNIC", &data{
 				action: action{
 					machineRequest: &driver.CreateMachineRequest{
 						Machine:      newMachine("dummy-machine"),
-						MachineClass: newGCPMachineClass(gcpProviderSpecNoKmsKeyName, ""),
+						MachineClass: newGCPMachineClass(gcpProviderSpecTier1VirtioNet, ""),
 						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
 					errToHaveOccurred: true,
-					errMessage:        FailAtSpecValidationNoKmsKeyName,
+					errMessage:        FailAtSpecValidationTier1WithoutGVNIC,
 				},
 			}),
-		)
-	})
-	Describe("##DeleteMachine", func() {
-		type action struct {
-			machineRequest *driver.DeleteMachineRequest
-		}
-		type expect struct {
-			machineResponse   *driver.DeleteMachineResponse
-			errToHaveOccurred bool
-			errMessage        string
-		}
-		type data struct {
-			action action
-			expect expect
-		}
-
-		DescribeTable("###table",
-			func(data *data) {
-
-				ctx := context.Background()
-				_, err := ms.DeleteMachine(ctx, data.action.machineRequest)
-				if data.expect.errToHaveOccurred {
-					Expect(err).To(HaveOccurred())
-					Expect(err.Error()).To(Equal(data.expect.errMessage))
-				} else {
-					Expect(err).ToNot(HaveOccurred())
-				}
-			},
-			Entry("Delete a non-existent machine", &data{
+			Entry("Create a machine in a Shared VPC host project", &data{
 				action: action{
-					machineRequest: &driver.DeleteMachineRequest{
-						Machine:      newMachine("non-existent-dummy-machine"),
-						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecSharedVPCHostProject, ""),
 						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
-					machineResponse:   &driver.DeleteMachineResponse{},
-					errToHaveOccurred: true,
-					errMessage:        FailAtNotFound,
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.NetworkInterfaces[0].Network).To(Equal("projects/dummy-host-project/global/networks/dummyShoot"))
+						Expect(instance.NetworkInterfaces[0].Subnetwork).To(Equal("projects/dummy-host-project/regions/europe-dummy/subnetworks/dummyShoot"))
+					},
 				},
 			}),
-
-			Entry("Delete machine request with unsupported provider in the MachineClass", &data{
+			Entry("Create a machine with network and subnetwork self-links", &data{
 				action: action{
-					machineRequest: &driver.DeleteMachineRequest{
+					machineRequest: &driver.CreateMachineRequest{
 						Machine:      newMachine("dummy-machine"),
-						MachineClass: newGCPMachineClass(gcpProviderSpec, "aws"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecSharedVPCSelfLinks, ""),
 						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
-					errToHaveOccurred: true,
-					errMessage:        UnsupportedProviderError,
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.NetworkInterfaces[0].Network).To(Equal("https://www.googleapis.com/compute/v1/projects/dummy-host-project/global/networks/dummyShoot"))
+						Expect(instance.NetworkInterfaces[0].Subnetwork).To(Equal("projects/dummy-host-project/regions/europe-dummy/subnetworks/dummyShoot"))
+					},
 				},
 			}),
-
-			Entry("With no provider spec", &data{
+			Entry("Create a machine with an invalid subnetwork URL", &data{
 				action: action{
-					machineRequest: &driver.DeleteMachineRequest{
+					machineRequest: &driver.CreateMachineRequest{
 						Machine:      newMachine("dummy-machine"),
-						MachineClass: newGCPMachineClass([]byte(""), ""),
+						MachineClass: newGCPMachineClass(gcpProviderSpecSharedVPCInvalidSubnetwork, ""),
 						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
 					errToHaveOccurred: true,
-					errMessage:        DeleteFailAtJSONUnmarshalling,
+					errMessage:        FailAtSpecValidationInvalidSubnetworkURL,
 				},
 			}),
-
-			Entry("With invalid list zone", &data{
+			Entry("Create a machine with static internal and external IPs", &data{
 				action: action{
-					machineRequest: &driver.DeleteMachineRequest{
+					machineRequest: &driver.CreateMachineRequest{
 						Machine:      newMachine("dummy-machine"),
-						MachineClass: newGCPMachineClass(gcpProviderSpecInvalidListZone, ""),
+						MachineClass: newGCPMachineClass(gcpProviderSpecStaticIPs, ""),
 						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
-					errToHaveOccurred: true,
-					errMessage:        DeleteFailAtInvalidZoneListCall,
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.NetworkInterfaces[0].NetworkIP).To(Equal("10.250.0.10"))
+						Expect(instance.NetworkInterfaces[0].AccessConfigs).To(Equal([]*compute.AccessConfig{{
+							NatIP:               "203.0.113.10",
+							NetworkTier:         "STANDARD",
+							PublicPtrDomainName: "egress.example.com.",
+							SetPublicPtr:        true,
+						}}))
+					},
 				},
 			}),
-		)
-	})
-	Describe("##ListMachines", func() {
-		type action struct {
-			createMachine bool
-			createRequest *driver.CreateMachineRequest
-			listRequest   *driver.ListMachinesRequest
-		}
-		type expect struct {
-			createResponse          *driver.CreateMachineResponse
-			errToHaveOccurred       bool
-			listErrToHaveOccurred   bool
-			createErrToHaveOccurred bool
-			machineCount            int
-			errMessage              string
-		}
-		type data struct {
-			action action
-			expect expect
-		}
-
-		var listResponse *driver.ListMachinesResponse
-		var createResponse *driver.CreateMachineResponse
-		var listErr error
-		var createErr error
-		DescribeTable("###table",
-			func(data *data) {
-
-				ctx := context.Background()
-				createErr = nil
-				listErr = nil
-				if data.action.createMachine {
-					createResponse, createErr = ms.CreateMachine(ctx, data.action.createRequest)
-				}
-				listResponse, listErr = ms.ListMachines(ctx, data.action.listRequest)
-				if data.expect.errToHaveOccurred {
-					if data.expect.createErrToHaveOccurred {
-						Expect(createErr).To(HaveOccurred())
-						Expect(createErr.Error()).To(Equal(data.expect.errMessage))
-					}
-					if data.expect.listErrToHaveOccurred {
-						Expect(listErr).To(HaveOccurred())
-						Expect(listErr.Error()).To(Equal(data.expect.errMessage))
-					}
-				} else {
-					Expect(createErr).ToNot(HaveOccurred())
-					Expect(listErr).ToNot(HaveOccurred())
-					if data.action.createMachine {
-						Expect(data.expect.createResponse.ProviderID).To(Equal(createResponse.ProviderID))
-					}
-					Expect(data.expect.machineCount).To(Equal(len(listResponse.MachineList)))
-				}
-
-			},
-			Entry("Create and List a simple machine", &data{
+			Entry("Create a machine with a static external IP and external IP disabled", &data{
 				action: action{
-					createRequest: &driver.CreateMachineRequest{
+					machineRequest: &driver.CreateMachineRequest{
 						Machine:      newMachine("dummy-machine"),
-						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+						MachineClass: newGCPMachineClass(gcpProviderSpecStaticIPsExternalIPDisabled, ""),
 						Secret:       newSecret(gcpProviderSecret),
 					},
-					createMachine: true,
-					listRequest: &driver.ListMachinesRequest{
-						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationNatIPWithExternalIPDisabled,
+				},
+			}),
+			Entry("Create a machine with multiple alias IP ranges", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecAliasIPRanges, ""),
 						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
-					createResponse: &driver.CreateMachineResponse{
+					machineResponse: &driver.CreateMachineResponse{
 						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
 						NodeName:   "dummy-machine",
 					},
 					errToHaveOccurred: false,
-					machineCount:      1,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.NetworkInterfaces[0].AliasIpRanges).To(Equal([]*compute.AliasIpRange{
+							{IpCidrRange: "/24", SubnetworkRangeName: "pods"},
+							{IpCidrRange: "/28", SubnetworkRangeName: "cni"},
+						}))
+					},
 				},
 			}),
-			Entry("List with no provider spec", &data{
+			Entry("Create a machine with an IPv6-only network interface", &data{
 				action: action{
-					createMachine: false,
-					listRequest: &driver.ListMachinesRequest{
-						MachineClass: newGCPMachineClass([]byte(""), ""),
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecIPv6Only, ""),
 						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
-					errToHaveOccurred:     true,
-					listErrToHaveOccurred: true,
-					errMessage:            ListFailAtJSONUnmarshalling,
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.NetworkInterfaces[0].StackType).To(Equal("IPV6_ONLY"))
+						Expect(instance.NetworkInterfaces[0].AccessConfigs).To(BeEmpty())
+						Expect(instance.NetworkInterfaces[0].Ipv6AccessConfigs).To(Equal([]*compute.AccessConfig{{Type: "DIRECT_IPV6"}}))
+						Expect(instance.NetworkInterfaces[0].AliasIpRanges).To(Equal([]*compute.AliasIpRange{{IpCidrRange: "/96"}}))
+					},
 				},
 			}),
-			Entry("List with Get call for invalid zone", &data{
+			Entry("Create an IPv6-only machine with ipCidrRange and subnetworkRangeName", &data{
 				action: action{
-					createMachine: false,
-					listRequest: &driver.ListMachinesRequest{
-						MachineClass: newGCPMachineClass(gcpProviderSpecInvalidListZone, ""),
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecIPv6OnlyLegacyAliasIPRange, ""),
 						Secret:       newSecret(gcpProviderSecret),
 					},
 				},
 				expect: expect{
-					errToHaveOccurred:     true,
-					listErrToHaveOccurred: true,
-					errMessage:            ListFailAtInvalidZoneListCall,
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationIPv6OnlyLegacyAliasIPRange,
+				},
+			}),
+			Entry("Create an IPv6-only machine with a network tier and a public PTR record", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecIPv6OnlyNetworkTier, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationIPv6OnlyNetworkTier,
+				},
+			}),
+			Entry("Create a machine with an IPv6 prefix length on an IPv4 alias IP range", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecIPv4AliasIPRangeTooLarge, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationIPv4AliasIPRangeTooLarge,
+				},
+			}),
+			Entry("Create a machine with an existing disk and disks seeded from a snapshot and a disk", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecDiskSources, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.Disks).To(HaveLen(4))
+						Expect(instance.Disks[1].Source).To(Equal("zones/europe-dummy/disks/image-cache"))
+						Expect(instance.Disks[1].Mode).To(Equal("READ_ONLY"))
+						Expect(instance.Disks[1].InitializeParams).To(BeNil())
+						Expect(instance.Disks[1].AutoDelete).To(BeFalse())
+						Expect(instance.Disks[2].InitializeParams.SourceSnapshot).To(Equal("global/snapshots/image-cache-snapshot"))
+						Expect(instance.Disks[3].Source).To(Equal("zones/europe-dummy/disks/dummy-machine-disk-3"))
+						Expect(instance.Disks[3].AutoDelete).To(BeTrue())
+					},
+				},
+			}),
+			Entry("Create a machine with a disk seeded from several sources", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecDiskConflictingSources, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationDiskConflictingSources,
+				},
+			}),
+			Entry("Create a machine from an instance template", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecInstanceTemplate, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.MachineType).To(BeEmpty())
+						Expect(instance.Disks).To(BeEmpty())
+						Expect(instance.NetworkInterfaces).To(BeEmpty())
+						Expect(instance.Scheduling).To(BeNil())
+						// the metadata of the template is replaced, the instance always carries the user data
+						var metadataKeys []string
+						for _, item := range instance.Metadata.Items {
+							metadataKeys = append(metadataKeys, item.Key)
+						}
+						Expect(metadataKeys).To(ConsistOf("startup-script", "gcp", "mcm-machine-name"))
+						Expect(instance.Labels).To(Equal(map[string]string{
+							"name":             "test-mc-gcp",
+							"mcm-machine-name": "dummy-machine",
+							"mcm-cluster-name": "dummy-machine",
+							"mcm-node-role":    "mcm",
+						}))
+					},
+				},
+			}),
+			Entry("Create a machine from an instance template with an invalid URL", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecInvalidInstanceTemplate, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationInvalidInstanceTemplate,
+				},
+			}),
+			Entry("Create a machine with a custom machine type", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecCustomMachineType, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.MachineType).To(Equal("zones/europe-dummy/machineTypes/n2-custom-8-32768"))
+					},
+				},
+			}),
+			Entry("Create a machine with a custom machine type exceeding the memory per vCPU", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecCustomMachineTypeTooMuchMemory, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationCustomMachineTypeTooMuchMemory,
+				},
+			}),
+			Entry("Create a machine exposing more cores than its custom machine type has", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecCustomMachineTypeTooManyVisibleCores, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationVisibleCoreCountExceedsCores,
+				},
+			}),
+			Entry("Create a machine with SMT disabled and further advanced machine features", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecAdvancedMachineFeaturesFull, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.AdvancedMachineFeatures).To(Equal(&compute.AdvancedMachineFeatures{
+							ThreadsPerCore:            1,
+							VisibleCoreCount:          1,
+							TurboMode:                 "ALL_CORE_MAX",
+							EnableUefiNetworking:      true,
+							PerformanceMonitoringUnit: "STANDARD",
+						}))
+					},
+				},
+			}),
+			Entry("Create a machine with an invalid number of threads per core", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecAdvancedMachineFeaturesInvalidThreads, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationInvalidThreadsPerCore,
+				},
+			}),
+			Entry("Create a machine with Resource Manager tags on the instance and disk", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecResourceManagerTags, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.Params.ResourceManagerTags).To(Equal(map[string]string{"tagKeys/281475012345678": "tagValues/281475087654321"}))
+						Expect(instance.Disks[0].InitializeParams.ResourceManagerTags).To(Equal(map[string]string{"tagKeys/281475012345678": "tagValues/281475087654321"}))
+					},
+				},
+			}),
+			Entry("Create a machine with an invalid Resource Manager tag value", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecInvalidResourceManagerTags, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationInvalidResourceManagerTagValue,
+				},
+			}),
+			Entry("Create a machine with invalid labels", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecInvalidLabels, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationInvalidLabels,
+				},
+			}),
+			Entry("Create a machine with sanitized labels and tags", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecSanitizedLabels, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.Labels).To(HaveKeyWithValue("app-kubernetes-io-name", "worker"))
+						Expect(instance.Tags.Items).To(ContainElement("kubernetes-io-role"))
+					},
+				},
+			}),
+			Entry("Create a machine with a custom hostname", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecHostname, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.Hostname).To(Equal("dummy-machine.nodes.example.com"))
+					},
+				},
+			}),
+			Entry("Create a machine with a hostname that is not a FQDN", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecHostnameSingleLabel, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationHostnameSingleLabel,
+				},
+			}),
+			Entry("Create a machine with a regional persistent disk", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecRegionalDisk, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy-a/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.Disks[1].InitializeParams.ReplicaZones).To(Equal([]string{
+							"projects/sap-se-gcp-scp-k8s-dev/zones/europe-dummy-a",
+							"projects/sap-se-gcp-scp-k8s-dev/zones/europe-dummy-b",
+						}))
+					},
+				},
+			}),
+			Entry("Create a machine with provisioned IOPS on a disk type that does not support them", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecProvisionedIopsNotSupported, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationProvisionedIopsNotSupported,
+				},
+			}),
+			Entry("Create a machine with more provisioned IOPS than the Hyperdisk size allows", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecProvisionedIopsOutOfRange, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationProvisionedIopsOutOfRange,
+				},
+			}),
+			Entry("Create a machine with ambiguous cluster tags", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecAmbiguousClusterTags, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationAmbiguousClusterTags,
+				},
+			}),
+			Entry("Create a machine with disks encrypted with customer-supplied keys", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecCustomerSuppliedKey, ""),
+						Secret:       newSecret(gcpProviderSecretWithCustomerSuppliedKeys),
+					},
+				},
+				expect: expect{
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.Disks[0].DiskEncryptionKey).To(Equal(&compute.CustomerEncryptionKey{RawKey: "SGVsbG8gZnJvbSBHb29nbGUgQ2xvdWQgUGxhdGZvcm0="}))
+						Expect(instance.Disks[0].InitializeParams.SourceImageEncryptionKey.RsaEncryptedKey).To(HavePrefix("ieCx/NcW06PcT7Ep1X6LUTc/"))
+					},
+				},
+			}),
+			Entry("Create a machine from an encrypted image with a Google-managed disk encryption key", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecSourceImageKey, ""),
+						Secret:       newSecret(gcpProviderSecretWithCustomerSuppliedKeys),
+					},
+				},
+				expect: expect{
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.Disks[0].DiskEncryptionKey).To(BeNil())
+						Expect(instance.Disks[0].InitializeParams.SourceImageEncryptionKey.RsaEncryptedKey).To(HavePrefix("ieCx/NcW06PcT7Ep1X6LUTc/"))
+					},
+				},
+			}),
+			Entry("Create a machine with a customer-supplied key missing in the secret", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecCustomerSuppliedKey, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSecretValidationMissingCustomerSuppliedKey,
+				},
+			}),
+			Entry("Create a machine with a disk encrypted with both a cloud kms and a customer-supplied key", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecCustomerSuppliedKeyWithKmsKeyName, ""),
+						Secret:       newSecret(gcpProviderSecretWithCustomerSuppliedKeys),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationCustomerSuppliedKeyWithKmsKeyName,
+				},
+			}),
+			Entry("Machine creation with disk type as PD balanced", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecPDBalanced, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					machineResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					instance: func(instance *compute.Instance) {
+						Expect(instance.Disks[0].InitializeParams.DiskType).To(Equal("zones/europe-dummy/diskTypes/pd-balanced"))
+					},
+				},
+			}),
+			Entry("Create a simple machine with unsupported provider in MachineClass", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpec, "aws"),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        UnsupportedProviderError,
+				},
+			}),
+			Entry("With zone missing", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecValidationErr, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidation,
+				},
+			}),
+			Entry("With no provider spec", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass([]byte(""), ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        CreateFailAtJSONUnmarshalling,
+				},
+			}),
+
+			Entry("With no secrets", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+						Secret:       newSecret(make(map[string][]byte)),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtNoSecretsPassed,
+				},
+			}),
+			Entry("With secrets missing user data", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+						Secret:       newSecret(gcpProviderSecretWithMisssingUserData),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSecretsWithNoUserData,
+				},
+			}),
+			Entry("With secrets having invalid projectID value", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+						Secret:       newSecret(gcpProviderSecretWithoutProjectID),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtInvalidProjectID,
+				},
+			}),
+			Entry("With Post for invalid zone", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecInvalidPostZone, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtInvalidZonePostCall,
+				},
+			}),
+			Entry("With invalid list zone", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecInvalidListZone, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        CreateFailAtInvalidZoneListCall,
+				},
+			}),
+			Entry("With disk.Encryption.KmsKeyName specified and invalid disk.Encryption.KmsKeyServiceAccount", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecInvalidKmsKeyServiceAccount, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationInvalidKmsServiceAccount,
+				},
+			}),
+			Entry("With disk.Encryption.KmsKeyServiceAccount specified but no disk.Encryption.KmsKeyName", &data{
+				action: action{
+					machineRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecNoKmsKeyName, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtSpecValidationNoKmsKeyName,
+				},
+			}),
+		)
+		It("should delete the cloned disks if the insert operation of the instance fails", func() {
+			_, err := ms.CreateMachine(context.Background(), &driver.CreateMachineRequest{
+				Machine:      newMachine("dummy-machine"),
+				MachineClass: newGCPMachineClass(gcpProviderSpecDiskSourcesFailedOperation, ""),
+				Secret:       newSecret(gcpProviderSecret),
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(fake.Instances).To(BeEmpty())
+			Expect(fake.Disks).To(BeEmpty())
+		})
+		It("should reuse the clone of a source disk left over by a previous attempt to create the machine", func() {
+			fake.Disks = []*compute.Disk{{Name: "dummy-machine-disk-3", Labels: map[string]string{api.GCPMachineNameKey: "dummy-machine"}}}
+			_, err := ms.CreateMachine(context.Background(), &driver.CreateMachineRequest{
+				Machine:      newMachine("dummy-machine"),
+				MachineClass: newGCPMachineClass(gcpProviderSpecDiskSources, ""),
+				Secret:       newSecret(gcpProviderSecret),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fake.Instances).To(HaveLen(1))
+			Expect(fake.Disks).To(HaveLen(1))
+		})
+		It("should neither attach nor delete an existing disk that was not cloned for the machine", func() {
+			fake.Disks = []*compute.Disk{{Name: "dummy-machine-disk-3", Labels: map[string]string{api.GCPMachineNameKey: "other-machine"}}}
+			_, err := ms.CreateMachine(context.Background(), &driver.CreateMachineRequest{
+				Machine:      newMachine("dummy-machine"),
+				MachineClass: newGCPMachineClass(gcpProviderSpecDiskSources, ""),
+				Secret:       newSecret(gcpProviderSecret),
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("disk \"dummy-machine-disk-3\" already exists and was not cloned for machine \"dummy-machine\""))
+			Expect(fake.Instances).To(BeEmpty())
+			Expect(fake.Disks).To(HaveLen(1))
+			Expect(fake.Disks[0].Labels).To(HaveKeyWithValue(api.GCPMachineNameKey, "other-machine"))
+		})
+		It("should label the instance and its disks with the identity of the machine and its cluster", func() {
+			machine := newMachine("dummy-machine")
+			machine.Namespace = "shoot--dummy"
+			machine.Labels = map[string]string{v1alpha1.DefaultMachineDeploymentUniqueLabelKey: "abc12"}
+			machine.OwnerReferences = []metav1.OwnerReference{{Kind: "MachineSet", Name: "dummy-deployment-abc12"}}
+			machineClass := newGCPMachineClass(gcpProviderSpecDiskSources, "")
+			machineClass.Name = "dummy-class"
+
+			_, err := ms.CreateMachine(context.Background(), &driver.CreateMachineRequest{
+				Machine:      machine,
+				MachineClass: machineClass,
+				Secret:       newSecret(gcpProviderSecret),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			identityLabels := map[string]string{
+				"mcm-machine-name":       "dummy-machine",
+				"mcm-machine-namespace":  "shoot--dummy",
+				"mcm-machine-class":      "dummy-class",
+				"mcm-machine-deployment": "dummy-deployment",
+				"mcm-cluster-name":       "dummy-machine",
+				"mcm-node-role":          "mcm",
+			}
+			Expect(fake.Instances).To(HaveLen(1))
+			Expect(fake.Disks).To(HaveLen(1))
+			instance := fake.Instances[0]
+			for key, value := range identityLabels {
+				Expect(instance.Labels).To(HaveKeyWithValue(key, value))
+				Expect(instance.Disks[0].InitializeParams.Labels).To(HaveKeyWithValue(key, value))
+				Expect(instance.Disks[2].InitializeParams.Labels).To(HaveKeyWithValue(key, value))
+				Expect(fake.Disks[0].Labels).To(HaveKeyWithValue(key, value))
+			}
+			Expect(instance.Labels).To(HaveKeyWithValue("name", "test-mc-gcp"))
+			Expect(instance.Disks[0].InitializeParams.Labels).To(HaveKeyWithValue("name", "test-mc-gcp"))
+		})
+	})
+	Describe("##DeleteMachine", func() {
+		type action struct {
+			machineRequest *driver.DeleteMachineRequest
+		}
+		type expect struct {
+			machineResponse   *driver.DeleteMachineResponse
+			errToHaveOccurred bool
+			errMessage        string
+		}
+		type data struct {
+			action action
+			expect expect
+		}
+
+		DescribeTable("###table",
+			func(data *data) {
+
+				ctx := context.Background()
+				_, err := ms.DeleteMachine(ctx, data.action.machineRequest)
+				if data.expect.errToHaveOccurred {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal(data.expect.errMessage))
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry("Delete a non-existent machine", &data{
+				action: action{
+					machineRequest: &driver.DeleteMachineRequest{
+						Machine:      newMachine("non-existent-dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					machineResponse:   &driver.DeleteMachineResponse{},
+					errToHaveOccurred: true,
+					errMessage:        FailAtNotFound,
+				},
+			}),
+
+			Entry("Delete machine request with unsupported provider in the MachineClass", &data{
+				action: action{
+					machineRequest: &driver.DeleteMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpec, "aws"),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        UnsupportedProviderError,
+				},
+			}),
+
+			Entry("With no provider spec", &data{
+				action: action{
+					machineRequest: &driver.DeleteMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass([]byte(""), ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        DeleteFailAtJSONUnmarshalling,
+				},
+			}),
+
+			Entry("With invalid list zone", &data{
+				action: action{
+					machineRequest: &driver.DeleteMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecInvalidListZone, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        DeleteFailAtInvalidZoneListCall,
+				},
+			}),
+		)
+		It("should delete the instance of a machine class without cluster and role tags by name", func() {
+			ctx := context.Background()
+			_, err := ms.CreateMachine(ctx, &driver.CreateMachineRequest{Machine: newMachine("dummy-machine"), MachineClass: newGCPMachineClass(gcpProviderSpec, ""), Secret: newSecret(gcpProviderSecret)})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = ms.DeleteMachine(ctx, &driver.DeleteMachineRequest{Machine: newMachine("dummy-machine"), MachineClass: newGCPMachineClass(gcpProviderSpecNoTagsToSearch, ""), Secret: newSecret(gcpProviderSecret)})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fake.Instances).To(BeEmpty())
+
+			_, err = ms.DeleteMachine(ctx, &driver.DeleteMachineRequest{Machine: newMachine("dummy-machine"), MachineClass: newGCPMachineClass(gcpProviderSpecNoTagsToSearch, ""), Secret: newSecret(gcpProviderSecret)})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("[NotFound]"))
+		})
+		It("should delete the cloned disks left over by a machine without instance", func() {
+			fake.Disks = []*compute.Disk{
+				{Name: "dummy-machine-disk-3", Labels: map[string]string{api.GCPMachineNameKey: "dummy-machine"}},
+				{Name: "dummy-machine-disk-1", Labels: map[string]string{api.GCPMachineNameKey: "dummy-machine"}},
+				{Name: "image-cache"},
+			}
+			_, err := ms.DeleteMachine(context.Background(), &driver.DeleteMachineRequest{
+				Machine:      newMachine("dummy-machine"),
+				MachineClass: newGCPMachineClass(gcpProviderSpecDiskSources, ""),
+				Secret:       newSecret(gcpProviderSecret),
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("[NotFound]"))
+			// only the clone of the disk seeded from a source disk is deleted
+			Expect(fake.Disks).To(ConsistOf(
+				HaveField("Name", "dummy-machine-disk-1"),
+				HaveField("Name", "image-cache"),
+			))
+		})
+		It("should keep a cloned disk that is attached to an instance", func() {
+			fake.Disks = []*compute.Disk{
+				{Name: "dummy-machine-disk-3", Labels: map[string]string{api.GCPMachineNameKey: "dummy-machine"}, Users: []string{"zones/europe-dummy/instances/other-machine"}},
+			}
+			_, err := ms.DeleteMachine(context.Background(), &driver.DeleteMachineRequest{
+				Machine:      newMachine("dummy-machine"),
+				MachineClass: newGCPMachineClass(gcpProviderSpecDiskSources, ""),
+				Secret:       newSecret(gcpProviderSecret),
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(fake.Disks).To(HaveLen(1))
+		})
+	})
+	Describe("##ListMachines", func() {
+		type action struct {
+			createMachine bool
+			createRequest *driver.CreateMachineRequest
+			listRequest   *driver.ListMachinesRequest
+		}
+		type expect struct {
+			createResponse          *driver.CreateMachineResponse
+			errToHaveOccurred       bool
+			listErrToHaveOccurred   bool
+			createErrToHaveOccurred bool
+			machineCount            int
+			errMessage              string
+		}
+		type data struct {
+			action action
+			expect expect
+		}
+
+		var listResponse *driver.ListMachinesResponse
+		var createResponse *driver.CreateMachineResponse
+		var listErr error
+		var createErr error
+		DescribeTable("###table",
+			func(data *data) {
+
+				ctx := context.Background()
+				createErr = nil
+				listErr = nil
+				if data.action.createMachine {
+					createResponse, createErr = ms.CreateMachine(ctx, data.action.createRequest)
+				}
+				listResponse, listErr = ms.ListMachines(ctx, data.action.listRequest)
+				if data.expect.errToHaveOccurred {
+					if data.expect.createErrToHaveOccurred {
+						Expect(createErr).To(HaveOccurred())
+						Expect(createErr.Error()).To(Equal(data.expect.errMessage))
+					}
+					if data.expect.listErrToHaveOccurred {
+						Expect(listErr).To(HaveOccurred())
+						Expect(listErr.Error()).To(Equal(data.expect.errMessage))
+					}
+				} else {
+					Expect(createErr).ToNot(HaveOccurred())
+					Expect(listErr).ToNot(HaveOccurred())
+					if data.action.createMachine {
+						Expect(data.expect.createResponse.ProviderID).To(Equal(createResponse.ProviderID))
+					}
+					Expect(data.expect.machineCount).To(Equal(len(listResponse.MachineList)))
+				}
+
+			},
+			Entry("Create and List a simple machine", &data{
+				action: action{
+					createRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+					createMachine: true,
+					listRequest: &driver.ListMachinesRequest{
+						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					createResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					machineCount:      1,
+				},
+			}),
+			Entry("List with no provider spec", &data{
+				action: action{
+					createMachine: false,
+					listRequest: &driver.ListMachinesRequest{
+						MachineClass: newGCPMachineClass([]byte(""), ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred:     true,
+					listErrToHaveOccurred: true,
+					errMessage:            ListFailAtJSONUnmarshalling,
+				},
+			}),
+			Entry("List with Get call for invalid zone", &data{
+				action: action{
+					createMachine: false,
+					listRequest: &driver.ListMachinesRequest{
+						MachineClass: newGCPMachineClass(gcpProviderSpecInvalidListZone, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred:     true,
+					listErrToHaveOccurred: true,
+					errMessage:            ListFailAtInvalidZoneListCall,
+				},
+			}),
+			Entry("Create and List a machine of a class without cluster and role tags", &data{
+				action: action{
+					createRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecNoTagsToSearch, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+					createMachine: true,
+					listRequest: &driver.ListMachinesRequest{
+						MachineClass: newGCPMachineClass(gcpProviderSpecNoTagsToSearch, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred:     true,
+					listErrToHaveOccurred: true,
+					errMessage:            ListFailAtMissingClusterTags,
 				},
 			}),
 			Entry("List with Get call with unsupported provider in MachineClass", &data{
@@ -659,6 +1702,7 @@ var _ = Describe("#MachineController", func() {
 					if data.expect.getStatusErrToHaveOccurred {
 						Expect(getStatusErr).To(HaveOccurred())
 						Expect(getStatusErr.Error()).To(Equal(data.expect.errMessage))
+						Expect(getStatusResponse).To(Equal(data.expect.getStatusResponse))
 					}
 				} else {
 					Expect(createErr).ToNot(HaveOccurred())
@@ -712,6 +1756,128 @@ var _ = Describe("#MachineController", func() {
 					},
 				},
 			}),
+			Entry("Create and Get a machine of a class without cluster and role tags", &data{
+				action: action{
+					createRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecNoTagsToSearch, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+					createMachine: true,
+					getStatusRequest: &driver.GetMachineStatusRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecNoTagsToSearch, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					createResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					machineCount:      1,
+					getStatusResponse: &driver.GetMachineStatusResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+				},
+			}),
+			Entry("Create and Get a simple machine by its provider ID", &data{
+				action: action{
+					createRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+					createMachine: true,
+					getStatusRequest: &driver.GetMachineStatusRequest{
+						Machine:      newMachineWithProviderID("dummy-machine", "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					createResponse: &driver.CreateMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+					errToHaveOccurred: false,
+					machineCount:      1,
+					getStatusResponse: &driver.GetMachineStatusResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+				},
+			}),
+			Entry("Get status of a machine that belongs to another cluster", &data{
+				action: action{
+					createRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+					createMachine: true,
+					getStatusRequest: &driver.GetMachineStatusRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecOtherCluster, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred:          true,
+					getStatusErrToHaveOccurred: true,
+					errMessage:                 FailAtMachineOfOtherCluster,
+				},
+			}),
+			Entry("Get status of a machine that is still booting", &data{
+				action: action{
+					createRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecStaging, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+					createMachine: true,
+					getStatusRequest: &driver.GetMachineStatusRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecStaging, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred:          true,
+					getStatusErrToHaveOccurred: true,
+					errMessage:                 FailAtStagingMachine,
+					getStatusResponse: &driver.GetMachineStatusResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+				},
+			}),
+			Entry("Get status of a machine that was terminated", &data{
+				action: action{
+					createRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecTerminated, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+					createMachine: true,
+					getStatusRequest: &driver.GetMachineStatusRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecTerminated, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred:          true,
+					getStatusErrToHaveOccurred: true,
+					errMessage:                 FailAtTerminatedMachine,
+					getStatusResponse: &driver.GetMachineStatusResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+					},
+				},
+			}),
 			Entry("Get status with no provider spec", &data{
 				action: action{
 					createMachine: false,
@@ -743,6 +1909,169 @@ var _ = Describe("#MachineController", func() {
 				},
 			}),
 		)
+		It("should report a terminated machine that is being deleted as existing, so that it can be deleted", func() {
+			ctx := context.Background()
+			machineClass := newGCPMachineClass(gcpProviderSpecTerminated, "")
+			_, err := ms.CreateMachine(ctx, &driver.CreateMachineRequest{Machine: newMachine("dummy-machine"), MachineClass: machineClass, Secret: newSecret(gcpProviderSecret)})
+			Expect(err).ToNot(HaveOccurred())
+
+			machine := newDeletingMachine("dummy-machine")
+			getStatusResponse, err := ms.GetMachineStatus(ctx, &driver.GetMachineStatusRequest{Machine: machine, MachineClass: machineClass, Secret: newSecret(gcpProviderSecret)})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getStatusResponse).To(Equal(&driver.GetMachineStatusResponse{
+				ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+				NodeName:   "dummy-machine",
+			}))
+
+			_, err = ms.DeleteMachine(ctx, &driver.DeleteMachineRequest{Machine: machine, MachineClass: machineClass, Secret: newSecret(gcpProviderSecret)})
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("should only record failed instance get calls as failed GCP API calls", func() {
+			ctx := context.Background()
+			metrics.APIRequestCount.Reset()
+			metrics.APIFailedRequestCount.Reset()
+			machineClass := newGCPMachineClass(gcpProviderSpecTerminated, "")
+			_, err := ms.GetMachineStatus(ctx, &driver.GetMachineStatusRequest{Machine: newMachine("dummy-machine"), MachineClass: machineClass, Secret: newSecret(gcpProviderSecret)})
+			Expect(err).To(HaveOccurred())
+			_, err = ms.CreateMachine(ctx, &driver.CreateMachineRequest{Machine: newMachine("dummy-machine"), MachineClass: machineClass, Secret: newSecret(gcpProviderSecret)})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = ms.GetMachineStatus(ctx, &driver.GetMachineStatusRequest{Machine: newMachine("dummy-machine"), MachineClass: machineClass, Secret: newSecret(gcpProviderSecret)})
+			Expect(err).To(HaveOccurred())
+
+			Expect(testutil.ToFloat64(metrics.APIRequestCount.WithLabelValues("gcp", instanceGetServiceLabel))).To(Equal(float64(2)))
+			Expect(testutil.ToFloat64(metrics.APIFailedRequestCount.WithLabelValues("gcp", instanceGetServiceLabel))).To(BeZero())
+		})
+	})
+	Describe("##InitializeMachine", func() {
+		type action struct {
+			createRequest     *driver.CreateMachineRequest
+			initializeRequest *driver.InitializeMachineRequest
+		}
+		type expect struct {
+			initializeResponse *driver.InitializeMachineResponse
+			errToHaveOccurred  bool
+			errMessage         string
+		}
+		type data struct {
+			action action
+			expect expect
+		}
+
+		DescribeTable("###table",
+			func(data *data) {
+				ctx := context.Background()
+
+				if data.action.createRequest != nil {
+					_, err := ms.CreateMachine(ctx, data.action.createRequest)
+					Expect(err).ToNot(HaveOccurred())
+				}
+				response, err := ms.InitializeMachine(ctx, data.action.initializeRequest)
+				if data.expect.errToHaveOccurred {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal(data.expect.errMessage))
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(response).To(Equal(data.expect.initializeResponse))
+				}
+			},
+			Entry("Create and initialize a simple machine", &data{
+				action: action{
+					createRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+					initializeRequest: &driver.InitializeMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					initializeResponse: &driver.InitializeMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "dummy-machine",
+						Addresses: []corev1.NodeAddress{
+							{Type: corev1.NodeInternalIP, Address: "10.250.0.2"},
+							{Type: corev1.NodeExternalIP, Address: "198.51.100.2"},
+						},
+					},
+				},
+			}),
+			Entry("Initialize a machine by its provider ID", &data{
+				action: action{
+					createRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+					initializeRequest: &driver.InitializeMachineRequest{
+						Machine:      newMachineWithProviderID("renamed-dummy-machine", "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					initializeResponse: &driver.InitializeMachineResponse{
+						ProviderID: "gce:///sap-se-gcp-scp-k8s-dev/europe-dummy/dummy-machine",
+						NodeName:   "renamed-dummy-machine",
+						Addresses: []corev1.NodeAddress{
+							{Type: corev1.NodeInternalIP, Address: "10.250.0.2"},
+							{Type: corev1.NodeExternalIP, Address: "198.51.100.2"},
+						},
+					},
+				},
+			}),
+			Entry("Initialize a non-existent machine", &data{
+				action: action{
+					initializeRequest: &driver.InitializeMachineRequest{
+						Machine:      newMachine("non-existent-dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpec, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        FailAtNotFound,
+				},
+			}),
+			Entry("Initialize a machine that is still staging", &data{
+				action: action{
+					createRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecStaging, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+					initializeRequest: &driver.InitializeMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecStaging, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        InitializeFailAtStagingMachine,
+				},
+			}),
+			Entry("Initialize a machine that was terminated during boot", &data{
+				action: action{
+					createRequest: &driver.CreateMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecTerminated, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+					initializeRequest: &driver.InitializeMachineRequest{
+						Machine:      newMachine("dummy-machine"),
+						MachineClass: newGCPMachineClass(gcpProviderSpecTerminated, ""),
+						Secret:       newSecret(gcpProviderSecret),
+					},
+				},
+				expect: expect{
+					errToHaveOccurred: true,
+					errMessage:        InitializeFailAtTerminatedMachine,
+				},
+			}),
+		)
 	})
 	Describe("##GetVolumeIDs", func() {
 		type action struct {
@@ -841,6 +2170,150 @@ var _ = Describe("#MachineController", func() {
 			}),
 		)
 	})
+	Describe("##checkIfResourceExhaustedError", func() {
+		DescribeTable("###table",
+			func(opErr *compute.OperationErrorErrors, expectResourceExhausted bool) {
+				err := checkIfResourceExhaustedError(opErr, []string{opErr.Message})
+				Expect(err).To(HaveOccurred())
+				_, isResourceExhausted := err.(*errors2.MachineResourceExhaustedError)
+				Expect(isResourceExhausted).To(Equal(expectResourceExhausted))
+			},
+			Entry("zone resource pool exhausted", &compute.OperationErrorErrors{Code: "ZONE_RESOURCE_POOL_EXHAUSTED", Message: "The zone does not have enough resources"}, true),
+			Entry("quota exceeded", &compute.OperationErrorErrors{Code: "QUOTA_EXCEEDED", Message: "Quota 'CPUS' exceeded"}, true),
+			Entry("specific reservation exhausted", &compute.OperationErrorErrors{Code: "CONDITION_NOT_MET", Message: "Specified reservation 'dummy-reservation' does not have available resources for the request."}, true),
+			Entry("compact placement policy without capacity", &compute.OperationErrorErrors{Code: "CONDITION_NOT_MET", Message: "The placement policy 'dummy-policy' does not have enough resources available to fulfill the request."}, true),
+			Entry("placement policy not supported by the machine type", &compute.OperationErrorErrors{Code: "UNSUPPORTED_OPERATION", Message: "The placement policy 'dummy-policy' is not supported for machine type 'e2-medium'."}, false),
+			Entry("unrelated error with a placement code", &compute.OperationErrorErrors{Code: "INVALID_PLACEMENT_POLICY", Message: "Invalid value for field 'resource.resourcePolicies[0]'"}, false),
+			Entry("operation rate exceeded", &compute.OperationErrorErrors{Code: "RESOURCE_OPERATION_RATE_EXCEEDED", Message: "Operation rate exceeded for resource 'dummy-machine'."}, false),
+			Entry("unrelated error", &compute.OperationErrorErrors{Code: "INVALID_USAGE", Message: "Invalid value for field 'resource.machineType'"}, false),
+		)
+	})
+	Describe("##sanitizeLabelsAndTags", func() {
+		It("should convert labels and tags into valid GCE labels and tags", func() {
+			providerSpec := &api.GCPProviderSpec{
+				Labels: map[string]string{"app.kubernetes.io/name": "Worker", "1st": "", "App.Kubernetes.IO/Name": "Frontend"},
+				Disks:  []*api.GCPDisk{{Labels: map[string]string{"Owner": "Team A"}}},
+				Tags:   []string{"Kubernetes_IO_Role", "kubernetes-io-role", "test-", "9"},
+			}
+			sanitizeLabelsAndTags(providerSpec)
+			Expect(providerSpec.Labels).To(Equal(map[string]string{"app-kubernetes-io-name": "frontend", "x1st": ""}))
+			Expect(providerSpec.Disks[0].Labels).To(Equal(map[string]string{"owner": "team-a"}))
+			Expect(providerSpec.Tags).To(Equal([]string{"kubernetes-io-role", "test", "x9"}))
+		})
+	})
+	Describe("##checkInstanceRunning", func() {
+		DescribeTable("###table",
+			func(status string, expectedCode codes.Code) {
+				err := checkInstanceRunning(&compute.Instance{Name: "dummy-machine", Status: status})
+				if expectedCode == codes.OK {
+					Expect(err).ToNot(HaveOccurred())
+					return
+				}
+				Expect(err).To(BeAssignableToTypeOf(&errors2.MachineNotRunningError{}))
+				Expect(err.(*errors2.MachineNotRunningError).Code).To(Equal(expectedCode))
+			},
+			Entry("RUNNING", "RUNNING", codes.OK),
+			Entry("PROVISIONING", "PROVISIONING", codes.Uninitialized),
+			Entry("STAGING", "STAGING", codes.Uninitialized),
+			Entry("REPAIRING", "REPAIRING", codes.Uninitialized),
+			Entry("STOPPING", "STOPPING", codes.Unavailable),
+			Entry("SUSPENDING", "SUSPENDING", codes.Unavailable),
+			Entry("STOPPED", "STOPPED", codes.FailedPrecondition),
+			Entry("SUSPENDED", "SUSPENDED", codes.FailedPrecondition),
+			Entry("TERMINATED", "TERMINATED", codes.Aborted),
+			Entry("DEPROVISIONING", "DEPROVISIONING", codes.Aborted),
+			Entry("unknown status", "HIBERNATING", codes.Unknown),
+		)
+	})
+	Describe("##instanceListFilter", func() {
+		It("should select the instances of the cluster and role", func() {
+			Expect(instanceListFilter("kubernetes-io-cluster-shoot--foo--bar", "kubernetes-io-role-node", "")).
+				To(Equal(`(tags.items = "kubernetes-io-cluster-shoot--foo--bar") (tags.items = "kubernetes-io-role-node")`))
+		})
+		It("should additionally select the instance by name", func() {
+			Expect(instanceListFilter("kubernetes-io-cluster-shoot--foo--bar", "kubernetes-io-role-node", "dummy-machine")).
+				To(Equal(`(tags.items = "kubernetes-io-cluster-shoot--foo--bar") (tags.items = "kubernetes-io-role-node") (name = "dummy-machine")`))
+		})
+	})
+	Describe("##clusterOwnershipLabels", func() {
+		It("should derive the labels from the cluster and role tags", func() {
+			Expect(clusterOwnershipLabels([]string{"kubernetes-io-cluster-shoot--foo--bar", "kubernetes-io-role-node", "shoot--foo--bar-nodes"})).
+				To(Equal(map[string]string{api.GCPClusterNameKey: "shoot--foo--bar", api.GCPNodeRoleKey: "node"}))
+		})
+		It("should not match tags only containing the prefix", func() {
+			Expect(clusterOwnershipLabels([]string{"legacy-kubernetes-io-cluster-shoot--foo--bar", "kubernetes-io-role-node"})).To(BeNil())
+		})
+		It("should not derive labels from ambiguous tags", func() {
+			Expect(clusterOwnershipLabels([]string{"kubernetes-io-cluster-shoot--foo--bar", "kubernetes-io-cluster-shoot--foo--bar2", "kubernetes-io-role-node"})).To(BeNil())
+		})
+	})
+	Describe("##isOwnedBy", func() {
+		ownershipLabels := map[string]string{api.GCPClusterNameKey: "shoot--foo--bar", api.GCPNodeRoleKey: "node"}
+		DescribeTable("###table",
+			func(labels map[string]string, tags []string, expectedOwned bool) {
+				instance := &compute.Instance{Labels: labels, Tags: &compute.Tags{Items: tags}}
+				Expect(isOwnedBy(instance, ownershipLabels)).To(Equal(expectedOwned))
+			},
+			Entry("instance with matching ownership labels",
+				map[string]string{api.GCPClusterNameKey: "shoot--foo--bar", api.GCPNodeRoleKey: "node"},
+				[]string{"kubernetes-io-cluster-shoot--foo--bar", "kubernetes-io-role-node"}, true),
+			Entry("instance with ownership labels of a similarly named cluster",
+				map[string]string{api.GCPClusterNameKey: "shoot--foo--bar2", api.GCPNodeRoleKey: "node"},
+				[]string{"kubernetes-io-cluster-shoot--foo--bar2", "kubernetes-io-role-node"}, false),
+			Entry("instance with ownership labels of another cluster taking precedence over its tags",
+				map[string]string{api.GCPClusterNameKey: "shoot--foo--baz", api.GCPNodeRoleKey: "node"},
+				[]string{"kubernetes-io-cluster-shoot--foo--bar", "kubernetes-io-role-node"}, false),
+			Entry("legacy instance with matching tags", nil,
+				[]string{"kubernetes-io-cluster-shoot--foo--bar", "kubernetes-io-role-node", "shoot--foo--bar-nodes"}, true),
+			Entry("legacy instance with tags of a similarly named cluster", nil,
+				[]string{"kubernetes-io-cluster-shoot--foo--bar2", "kubernetes-io-role-node"}, false),
+			Entry("legacy instance with tags of another role", nil,
+				[]string{"kubernetes-io-cluster-shoot--foo--bar", "kubernetes-io-role-bastion"}, false),
+			Entry("legacy instance without tags", nil, nil, false),
+		)
+	})
+	Describe("##decodeMachineID", func() {
+		It("should decode the project, zone and name of an encoded machine ID", func() {
+			project, zone, name, err := decodeMachineID(encodeMachineID("my-project", "europe-west1-b", "my-machine"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect([]string{project, zone, name}).To(Equal([]string{"my-project", "europe-west1-b", "my-machine"}))
+		})
+		It("should reject malformed machine IDs", func() {
+			for _, machineID := range []string{"my-machine", "gce:///my-project/my-machine", "aws:///my-project/europe-west1-b/my-machine", "gce:///my-project//my-machine"} {
+				_, _, _, err := decodeMachineID(machineID)
+				Expect(err).To(HaveOccurred(), machineID)
+			}
+		})
+	})
+	Describe("##machineIdentityLabels", func() {
+		DescribeTable("###table",
+			func(machine *v1alpha1.Machine, machineClassName string, expectedLabels map[string]string) {
+				machineClass := newGCPMachineClass(gcpProviderSpec, "")
+				machineClass.Name = machineClassName
+				Expect(machineIdentityLabels(machine, machineClass)).To(Equal(expectedLabels))
+			},
+			Entry("machine of a machine deployment", &v1alpha1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "shoot--dev--worker-z1-5d8f7-abcde",
+					Namespace:       "shoot--dev--cluster",
+					Labels:          map[string]string{v1alpha1.DefaultMachineDeploymentUniqueLabelKey: "5d8f7"},
+					OwnerReferences: []metav1.OwnerReference{{Kind: "MachineSet", Name: "shoot--dev--worker-z1-5d8f7"}},
+				},
+			}, "worker-z1.v2", map[string]string{
+				"mcm-machine-name":       "shoot--dev--worker-z1-5d8f7-abcde",
+				"mcm-machine-namespace":  "shoot--dev--cluster",
+				"mcm-machine-class":      "worker-z1-v2",
+				"mcm-machine-deployment": "shoot--dev--worker-z1",
+			}),
+			Entry("standalone machine with a long name", &v1alpha1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "Standalone-Machine-With-A-Very-Long-Name-That-Exceeds-The-Label-Value-Limit",
+				},
+			}, "", map[string]string{
+				"mcm-machine-name": "standalone-machine-with-a-very-long-name-that-exceeds-the-label",
+			}),
+		)
+	})
 })
 
 func newMachine(name string) *v1alpha1.Machine {
@@ -851,6 +2324,18 @@ func newMachine(name string) *v1alpha1.Machine {
 	}
 }
 
+func newMachineWithProviderID(name, providerID string) *v1alpha1.Machine {
+	machine := newMachine(name)
+	machine.Spec.ProviderID = providerID
+	return machine
+}
+
+func newDeletingMachine(name string) *v1alpha1.Machine {
+	machine := newMachine(name)
+	machine.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	return machine
+}
+
 func newGCPMachineClass(gcpProviderSpec []byte, provider string) *v1alpha1.MachineClass {
 	if provider == "" {
 		provider = ProviderGCP
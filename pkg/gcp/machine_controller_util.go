@@ -7,8 +7,13 @@ package gcp
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"net"
 	"net/http"
+	"reflect"
+	"slices"
 	"strings"
+	"text/template"
 	"time"
 
 	"k8s.io/utils/ptr"
@@ -22,6 +27,7 @@ import (
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
 	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 
@@ -39,11 +45,36 @@ const (
 	instanceDeleteServiceLabel = "instance_delete"
 	instanceListServiceLabel   = "instance_list"
 	instanceGetServiceLabel    = "instance_get"
+	addressGetServiceLabel     = "address_get"
+	diskInsertServiceLabel     = "disk_insert"
+	diskDeleteServiceLabel     = "disk_delete"
+	diskGetServiceLabel        = "disk_get"
 	operationGetServiceLabel   = "operations_get"
+
+	// statuses of an instance, see https://cloud.google.com/compute/docs/instances/instance-life-cycle
+	instanceStatusProvisioning   = "PROVISIONING"
+	instanceStatusStaging        = "STAGING"
+	instanceStatusRunning        = "RUNNING"
+	instanceStatusRepairing      = "REPAIRING"
+	instanceStatusStopping       = "STOPPING"
+	instanceStatusStopped        = "STOPPED"
+	instanceStatusSuspending     = "SUSPENDING"
+	instanceStatusSuspended      = "SUSPENDED"
+	instanceStatusTerminated     = "TERMINATED"
+	instanceStatusDeprovisioning = "DEPROVISIONING"
+)
+
+var (
+	// errMissingOwnershipTags is returned if the machines of the cluster cannot be listed, instead of silently
+	// finding none of them
+	errMissingOwnershipTags = fmt.Errorf("provider spec must have exactly one network tag with prefix %q and one with prefix %q to identify the machines of the cluster", api.GCPClusterTagPrefix, api.GCPNodeRoleTagPrefix)
+
+	// instanceListFields restricts the listed instances to the fields needed to identify the machines of a cluster
+	instanceListFields = []googleapi.Field{"nextPageToken", "items(name,labels,tags/items)"}
 )
 
 // CreateMachineUtil method is used to create a GCP machine
-func (ms *MachinePlugin) CreateMachineUtil(_ context.Context, machineName string, providerSpec *api.GCPProviderSpec, secret *corev1.Secret) (machineID string, err error) {
+func (ms *MachinePlugin) CreateMachineUtil(_ context.Context, machine *v1alpha1.Machine, machineClass *v1alpha1.MachineClass, providerSpec *api.GCPProviderSpec, secret *corev1.Secret) (machineID string, err error) {
 	defer instrument.GcpAPIMetricRecorderFn(instanceCreateServiceLabel, &err)()
 	ctx, computeService, err := ms.SPI.NewComputeService(secret)
 	if err != nil {
@@ -55,26 +86,67 @@ func (ms *MachinePlugin) CreateMachineUtil(_ context.Context, machineName string
 		return "", err
 	}
 	var (
-		zone = providerSpec.Zone
+		machineName    = machine.Name
+		identityLabels = mergeLabels(machineIdentityLabels(machine, machineClass), clusterOwnershipLabels(providerSpec.Tags))
+		zone           = providerSpec.Zone
 
 		instance = &compute.Instance{
 			CanIpForward:       providerSpec.CanIPForward,
 			DeletionProtection: providerSpec.DeletionProtection,
-			Labels:             providerSpec.Labels,
-			MachineType:        fmt.Sprintf("zones/%s/machineTypes/%s", zone, providerSpec.MachineType),
+			Labels:             mergeLabels(identityLabels, providerSpec.Labels),
 			MinCpuPlatform:     providerSpec.MinCPUPlatform,
 			Name:               machineName,
 			Scheduling: &compute.Scheduling{
-				AutomaticRestart:  &providerSpec.Scheduling.AutomaticRestart,
-				OnHostMaintenance: providerSpec.Scheduling.OnHostMaintenance,
-				Preemptible:       providerSpec.Scheduling.Preemptible,
-			},
-			Tags: &compute.Tags{
-				Items: providerSpec.Tags,
+				AutomaticRestart:          &providerSpec.Scheduling.AutomaticRestart,
+				OnHostMaintenance:         providerSpec.Scheduling.OnHostMaintenance,
+				Preemptible:               providerSpec.Scheduling.Preemptible,
+				ProvisioningModel:         providerSpec.Scheduling.ProvisioningModel,
+				InstanceTerminationAction: providerSpec.Scheduling.InstanceTerminationAction,
+				MinNodeCpus:               providerSpec.Scheduling.MinNodeCpus,
 			},
 		}
 	)
 
+	if providerSpec.Hostname != "" {
+		if instance.Hostname, err = renderHostname(providerSpec, machine); err != nil {
+			return "", status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if providerSpec.CustomMachineType != nil {
+		instance.MachineType = fmt.Sprintf("zones/%s/machineTypes/%s", zone, customMachineTypeName(providerSpec.CustomMachineType))
+	} else if providerSpec.MachineType != "" {
+		instance.MachineType = fmt.Sprintf("zones/%s/machineTypes/%s", zone, providerSpec.MachineType)
+	}
+	// the tags identify the instances of the cluster, so they override the tags of an instance template
+	instance.Tags = &compute.Tags{
+		Items: providerSpec.Tags,
+	}
+
+	if providerSpec.Scheduling.MaxRunDuration != nil {
+		instance.Scheduling.MaxRunDuration = &compute.Duration{
+			Seconds: int64(providerSpec.Scheduling.MaxRunDuration.Seconds()),
+		}
+	}
+	if providerSpec.Scheduling.TerminationTime != nil {
+		instance.Scheduling.TerminationTime = providerSpec.Scheduling.TerminationTime.UTC().Format(time.RFC3339)
+	}
+	for _, nodeAffinity := range providerSpec.Scheduling.NodeAffinities {
+		instance.Scheduling.NodeAffinities = append(instance.Scheduling.NodeAffinities, &compute.SchedulingNodeAffinity{
+			Key:      nodeAffinity.Key,
+			Operator: nodeAffinity.Operator,
+			Values:   nodeAffinity.Values,
+		})
+	}
+	if providerSpec.SourceInstanceTemplate != "" && reflect.DeepEqual(providerSpec.Scheduling, api.GCPScheduling{}) {
+		instance.Scheduling = nil
+	}
+
+	if len(providerSpec.ResourceManagerTags) != 0 {
+		instance.Params = &compute.InstanceParams{
+			ResourceManagerTags: providerSpec.ResourceManagerTags,
+		}
+	}
+
 	if providerSpec.Gpu != nil {
 		instance.GuestAccelerators = []*compute.AcceleratorConfig{
 			{
@@ -97,9 +169,36 @@ func (ms *MachinePlugin) CreateMachineUtil(_ context.Context, machineName string
 		}
 	}
 
+	if providerSpec.ConfidentialInstanceConfig != nil {
+		instance.ConfidentialInstanceConfig = &compute.ConfidentialInstanceConfig{
+			EnableConfidentialCompute: true,
+			ConfidentialInstanceType:  providerSpec.ConfidentialInstanceConfig.ConfidentialInstanceType,
+		}
+	}
+
+	if providerSpec.ReservationAffinity != nil {
+		instance.ReservationAffinity = &compute.ReservationAffinity{
+			ConsumeReservationType: providerSpec.ReservationAffinity.ConsumeReservationType,
+			Key:                    providerSpec.ReservationAffinity.Key,
+			Values:                 providerSpec.ReservationAffinity.Values,
+		}
+		if instance.ReservationAffinity.ConsumeReservationType == api.GCPConsumeSpecificReservation && instance.ReservationAffinity.Key == "" {
+			instance.ReservationAffinity.Key = api.GCPReservationNameKey
+		}
+	}
+
+	for _, resourcePolicy := range providerSpec.ResourcePolicies {
+		instance.ResourcePolicies = append(instance.ResourcePolicies, resourcePolicyURL(project, providerSpec.Region, resourcePolicy))
+	}
+
 	if providerSpec.AdvancedMachineFeatures != nil {
 		instance.AdvancedMachineFeatures = &compute.AdvancedMachineFeatures{
 			EnableNestedVirtualization: providerSpec.AdvancedMachineFeatures.EnableNestedVirtualization,
+			EnableUefiNetworking:       providerSpec.AdvancedMachineFeatures.EnableUefiNetworking,
+			PerformanceMonitoringUnit:  providerSpec.AdvancedMachineFeatures.PerformanceMonitoringUnit,
+			ThreadsPerCore:             providerSpec.AdvancedMachineFeatures.ThreadsPerCore,
+			TurboMode:                  providerSpec.AdvancedMachineFeatures.TurboMode,
+			VisibleCoreCount:           providerSpec.AdvancedMachineFeatures.VisibleCoreCount,
 		}
 	}
 
@@ -107,7 +206,17 @@ func (ms *MachinePlugin) CreateMachineUtil(_ context.Context, machineName string
 		instance.Description = *providerSpec.Description
 	}
 
-	instance.Disks = createAttachedDisks(providerSpec.Disks, zone, machineName)
+	clonedDisks, err := cloneSourceDisks(ctx, computeService, project, zone, machineName, providerSpec.Disks, identityLabels, secret.Data)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		// the clones are only attached to an instance that was created, a timed out operation may still succeed
+		if err != nil && !wait.Interrupted(err) {
+			deleteClonedDisks(ctx, computeService, project, zone, clonedDisks)
+		}
+	}()
+	instance.Disks = createAttachedDisks(providerSpec.Disks, project, zone, machineName, identityLabels, secret.Data)
 
 	var metadataItems []*compute.MetadataItems
 	metadataItems = append(metadataItems, getUserData(string(secret.Data["userData"])))
@@ -118,40 +227,74 @@ func (ms *MachinePlugin) CreateMachineUtil(_ context.Context, machineName string
 			Value: metadata.Value,
 		})
 	}
+	metadataItems = appendMachineIdentityMetadata(metadataItems, machine, machineClass)
 	instance.Metadata = &compute.Metadata{
 		Items: metadataItems,
 	}
 
 	var networkInterfaces []*compute.NetworkInterface
 	for _, nic := range providerSpec.NetworkInterfaces {
-		computeNIC := &compute.NetworkInterface{}
+		computeNIC := &compute.NetworkInterface{
+			NetworkIP:  nic.NetworkIP,
+			NicType:    nic.NicType,
+			QueueCount: nic.QueueCount,
+		}
 
-		if !nic.DisableExternalIP {
+		if !nic.DisableExternalIP && nic.StackType == api.GCPStackTypeIPv6Only {
+			// IPv6-only interfaces cannot carry an IPv4 access config
+			if nic.Ipv6AccessType == "EXTERNAL" {
+				computeNIC.Ipv6AccessConfigs = []*compute.AccessConfig{{Type: "DIRECT_IPV6"}}
+			}
+		} else if !nic.DisableExternalIP {
 			// When DisableExternalIP is false, implies Attach an external IP to VM
-			computeNIC.AccessConfigs = []*compute.AccessConfig{{}}
+			accessConfig := &compute.AccessConfig{
+				NetworkTier:         nic.NetworkTier,
+				PublicPtrDomainName: nic.PublicPtrDomainName,
+				SetPublicPtr:        nic.PublicPtrDomainName != "",
+			}
+			if nic.NatIP != "" {
+				if accessConfig.NatIP, err = resolveNatIP(ctx, computeService, project, providerSpec.Region, nic.NatIP); err != nil {
+					return "", err
+				}
+			}
+			computeNIC.AccessConfigs = []*compute.AccessConfig{accessConfig}
 		}
 		if len(nic.Network) != 0 {
-			computeNIC.Network = fmt.Sprintf("projects/%s/global/networks/%s", project, nic.Network)
+			computeNIC.Network = networkURL(project, nic)
 		}
 		if len(nic.Subnetwork) != 0 {
-			computeNIC.Subnetwork = fmt.Sprintf("regions/%s/subnetworks/%s", providerSpec.Region, nic.Subnetwork)
+			computeNIC.Subnetwork = subnetworkURL(providerSpec.Region, nic)
 		}
 
-		if nic.StackType == "IPV4_IPV6" || nic.UseAliasIPs {
+		if nic.StackType == api.GCPStackTypeIPv4IPv6 || nic.StackType == api.GCPStackTypeIPv6Only || nic.UseAliasIPs {
 			computeNIC.StackType = nic.StackType
 			computeNIC.Ipv6AccessType = nic.Ipv6AccessType
-			computeNIC.AliasIpRanges = []*compute.AliasIpRange{
-				{
-					IpCidrRange:         nic.IpCidrRange, // Specify the secondary IP alias range (for IPv4 Pods CIDR)
-					SubnetworkRangeName: nic.SubnetworkRangeName,
-				},
+			if len(nic.AliasIPRanges) == 0 && nic.StackType != api.GCPStackTypeIPv6Only {
+				computeNIC.AliasIpRanges = []*compute.AliasIpRange{
+					{
+						IpCidrRange:         nic.IpCidrRange, // Specify the secondary IP alias range (for IPv4 Pods CIDR)
+						SubnetworkRangeName: nic.SubnetworkRangeName,
+					},
+				}
 			}
 		}
+		for _, aliasIPRange := range nic.AliasIPRanges {
+			computeNIC.AliasIpRanges = append(computeNIC.AliasIpRanges, &compute.AliasIpRange{
+				IpCidrRange:         aliasIPRange.IpCidrRange,
+				SubnetworkRangeName: aliasIPRange.SubnetworkRangeName,
+			})
+		}
 
 		networkInterfaces = append(networkInterfaces, computeNIC)
 	}
 	instance.NetworkInterfaces = networkInterfaces
 
+	if providerSpec.NetworkPerformanceConfig != nil {
+		instance.NetworkPerformanceConfig = &compute.NetworkPerformanceConfig{
+			TotalEgressBandwidthTier: providerSpec.NetworkPerformanceConfig.TotalEgressBandwidthTier,
+		}
+	}
+
 	var serviceAccounts []*compute.ServiceAccount
 	for _, sa := range providerSpec.ServiceAccounts {
 		serviceAccounts = append(serviceAccounts, &compute.ServiceAccount{
@@ -160,7 +303,11 @@ func (ms *MachinePlugin) CreateMachineUtil(_ context.Context, machineName string
 		})
 	}
 	instance.ServiceAccounts = serviceAccounts
-	operation, err := computeService.Instances.Insert(project, zone, instance).Context(ctx).Do()
+	insertCall := computeService.Instances.Insert(project, zone, instance)
+	if providerSpec.SourceInstanceTemplate != "" {
+		insertCall = insertCall.SourceInstanceTemplate(instanceTemplateURL(project, providerSpec.SourceInstanceTemplate))
+	}
+	operation, err := insertCall.Context(ctx).Do()
 	if err != nil {
 		return "", classifyIfResourceExhaustedError(err)
 	}
@@ -172,9 +319,9 @@ func (ms *MachinePlugin) CreateMachineUtil(_ context.Context, machineName string
 	return encodeMachineID(project, zone, machineName), nil
 }
 
-func createAttachedDisks(disks []*api.GCPDisk, zone, machineName string) []*compute.AttachedDisk {
+func createAttachedDisks(disks []*api.GCPDisk, project, zone, machineName string, identityLabels map[string]string, secretData map[string][]byte) []*compute.AttachedDisk {
 	attachedDisks := make([]*compute.AttachedDisk, 0, len(disks))
-	for _, disk := range disks {
+	for i, disk := range disks {
 		var attachedDisk compute.AttachedDisk
 		switch disk.Type {
 		case api.GCPDiskTypeScratch:
@@ -188,39 +335,437 @@ func createAttachedDisks(disks []*api.GCPDisk, zone, machineName string) []*comp
 				},
 			}
 		default:
+			if disk.Source != "" || disk.SourceDisk != "" {
+				// attach an existing disk or the clone of the source disk, it is not (re-)initialized
+				source := disk.Source
+				if disk.SourceDisk != "" {
+					source = clonedDiskName(machineName, i)
+				}
+				attachedDisk = compute.AttachedDisk{
+					Type: api.GCPDiskTypePersistent,
+					Boot: disk.Boot,
+					// an existing disk does not belong to the machine and is kept by default, unlike the clone of a source disk
+					AutoDelete: ptr.Deref(disk.AutoDelete, disk.SourceDisk != ""),
+					Mode:       disk.Mode,
+					Source:     zonalDiskURL(zone, source),
+				}
+				break
+			}
 			attachedDisk = compute.AttachedDisk{
 				Type:       api.GCPDiskTypePersistent,
 				Boot:       disk.Boot,
 				AutoDelete: ptr.Deref(disk.AutoDelete, true),
+				Mode:       disk.Mode,
 				InitializeParams: &compute.AttachedDiskInitializeParams{
 					DiskSizeGb:            disk.SizeGb,
 					DiskType:              fmt.Sprintf("zones/%s/diskTypes/%s", zone, disk.Type),
-					Labels:                disk.Labels,
+					Labels:                mergeLabels(identityLabels, disk.Labels),
 					SourceImage:           disk.Image,
 					ProvisionedIops:       disk.ProvisionedIops,
 					ProvisionedThroughput: disk.ProvisionedThroughput,
 					StoragePool:           ptr.Deref(disk.StoragePool, ""),
+					ResourceManagerTags:   disk.ResourceManagerTags,
 				},
 			}
+			if disk.SourceSnapshot != "" {
+				attachedDisk.InitializeParams.SourceSnapshot = snapshotURL(disk.SourceSnapshot)
+			}
+			for _, replicaZone := range disk.ReplicaZones {
+				attachedDisk.InitializeParams.ReplicaZones = append(attachedDisk.InitializeParams.ReplicaZones, fmt.Sprintf("projects/%s/zones/%s", project, replicaZone))
+			}
 		}
 
 		if disk.Encryption != nil {
-			attachedDisk.DiskEncryptionKey = &compute.CustomerEncryptionKey{
-				KmsKeyName:           strings.TrimSpace(disk.Encryption.KmsKeyName),
-				KmsKeyServiceAccount: strings.TrimSpace(disk.Encryption.KmsKeyServiceAccount),
+			attachedDisk.DiskEncryptionKey = diskEncryptionKey(disk.Encryption, secretData)
+			if attachedDisk.InitializeParams != nil && disk.Encryption.SourceImageKey != nil {
+				attachedDisk.InitializeParams.SourceImageEncryptionKey = customerSuppliedKey(disk.Encryption.SourceImageKey, secretData)
 			}
 			klog.V(3).Infof("(CreateMachineUtil) For machineName: %q, diskLabel: %q, DiskEncryptionKey.KmsKeyName: %q, "+
-				"DiskEncryptionKey.KmsKeyServiceAccount: %q",
+				"DiskEncryptionKey.KmsKeyServiceAccount: %q, customer-supplied key: %t, source image key: %t",
 				machineName,
 				disk.Labels["name"],
-				attachedDisk.DiskEncryptionKey.KmsKeyName,
-				attachedDisk.DiskEncryptionKey.KmsKeyServiceAccount)
+				strings.TrimSpace(disk.Encryption.KmsKeyName),
+				strings.TrimSpace(disk.Encryption.KmsKeyServiceAccount),
+				disk.Encryption.CustomerSuppliedKey != nil,
+				disk.Encryption.SourceImageKey != nil)
 		}
 		attachedDisks = append(attachedDisks, &attachedDisk)
 	}
 	return attachedDisks
 }
 
+// diskEncryptionKey returns the encryption key of a disk, either a cloud kms key or a customer-supplied key read from
+// the secret data, or nil if the disk is encrypted with a Google-managed key
+func diskEncryptionKey(encryption *api.GCPDiskEncryption, secretData map[string][]byte) *compute.CustomerEncryptionKey {
+	if encryption.CustomerSuppliedKey != nil {
+		return customerSuppliedKey(encryption.CustomerSuppliedKey, secretData)
+	}
+	if strings.TrimSpace(encryption.KmsKeyName) == "" {
+		return nil
+	}
+	return &compute.CustomerEncryptionKey{
+		KmsKeyName:           strings.TrimSpace(encryption.KmsKeyName),
+		KmsKeyServiceAccount: strings.TrimSpace(encryption.KmsKeyServiceAccount),
+	}
+}
+
+// customerSuppliedKey returns the raw or RSA-wrapped key referenced by key from the secret data
+func customerSuppliedKey(key *api.GCPCustomerSuppliedKey, secretData map[string][]byte) *compute.CustomerEncryptionKey {
+	if key.RsaEncryptedKey != "" {
+		return &compute.CustomerEncryptionKey{
+			RsaEncryptedKey: strings.TrimSpace(string(secretData[key.RsaEncryptedKey])),
+		}
+	}
+	return &compute.CustomerEncryptionKey{
+		RawKey: strings.TrimSpace(string(secretData[key.RawKey])),
+	}
+}
+
+// cloneSourceDisks creates a clone of the source disk for every disk seeded from a source disk, as the
+// compute v1 API cannot initialize an attached disk from another disk. Already existing clones are reused.
+func cloneSourceDisks(ctx context.Context, computeService *compute.Service, project, zone, machineName string, disks []*api.GCPDisk, identityLabels map[string]string, secretData map[string][]byte) (clonedDisks []string, err error) {
+	for i, disk := range disks {
+		if disk.SourceDisk == "" {
+			continue
+		}
+		diskName := clonedDiskName(machineName, i)
+		if err := cloneSourceDisk(ctx, computeService, project, zone, diskName, disk, identityLabels, secretData); err != nil {
+			deleteClonedDisks(ctx, computeService, project, zone, clonedDisks)
+			return nil, err
+		}
+		clonedDisks = append(clonedDisks, diskName)
+	}
+	return clonedDisks, nil
+}
+
+func cloneSourceDisk(ctx context.Context, computeService *compute.Service, project, zone, diskName string, disk *api.GCPDisk, identityLabels map[string]string, secretData map[string][]byte) (err error) {
+	defer instrument.GcpAPIMetricRecorderFn(diskInsertServiceLabel, &err)()
+	clone := &compute.Disk{
+		Name:                  diskName,
+		SourceDisk:            zonalDiskURL(zone, disk.SourceDisk),
+		SizeGb:                disk.SizeGb,
+		Type:                  fmt.Sprintf("zones/%s/diskTypes/%s", zone, disk.Type),
+		Labels:                mergeLabels(identityLabels, disk.Labels),
+		ProvisionedIops:       disk.ProvisionedIops,
+		ProvisionedThroughput: disk.ProvisionedThroughput,
+		StoragePool:           ptr.Deref(disk.StoragePool, ""),
+	}
+	if len(disk.ResourceManagerTags) != 0 {
+		clone.Params = &compute.DiskParams{
+			ResourceManagerTags: disk.ResourceManagerTags,
+		}
+	}
+	if disk.Encryption != nil {
+		clone.DiskEncryptionKey = diskEncryptionKey(disk.Encryption, secretData)
+	}
+	operation, err := computeService.Disks.Insert(project, zone, clone).Context(ctx).Do()
+	if err != nil {
+		if ae, ok := err.(*googleapi.Error); ok && ae.Code == http.StatusConflict {
+			return reuseClonedDisk(ctx, computeService, project, zone, diskName, identityLabels[api.GCPMachineNameKey])
+		}
+		return fmt.Errorf("failed to clone source disk %q: %w", disk.SourceDisk, classifyIfResourceExhaustedError(err))
+	}
+	return WaitUntilOperationCompleted(computeService, project, zone, operation.Name)
+}
+
+// reuseClonedDisk checks that an existing disk is the clone of a previous attempt to create the machine, a disk that
+// was not cloned for the machine must neither be attached nor be deleted on failure
+func reuseClonedDisk(ctx context.Context, computeService *compute.Service, project, zone, diskName, machineNameLabel string) error {
+	existing, err := getDisk(ctx, computeService, project, zone, diskName)
+	if err != nil {
+		return fmt.Errorf("failed to get existing disk %q: %w", diskName, err)
+	}
+	if existing.Labels[api.GCPMachineNameKey] != machineNameLabel {
+		return fmt.Errorf("disk %q already exists and was not cloned for machine %q", diskName, machineNameLabel)
+	}
+	klog.V(3).Infof("Reusing existing clone %q of a source disk", diskName)
+	return nil
+}
+
+// deleteLeftoverClonedDisks deletes the clones of source disks created for the machine which are not attached to any
+// instance, disks that were not cloned for the machine are left untouched
+func deleteLeftoverClonedDisks(ctx context.Context, computeService *compute.Service, project, zone, machineName string, disks []*api.GCPDisk) error {
+	var errs []error
+	for i, disk := range disks {
+		if disk.SourceDisk == "" {
+			continue
+		}
+		diskName := clonedDiskName(machineName, i)
+		clone, err := getDisk(ctx, computeService, project, zone, diskName)
+		if err != nil {
+			if ae, ok := err.(*googleapi.Error); !ok || ae.Code != http.StatusNotFound {
+				errs = append(errs, fmt.Errorf("failed to get cloned disk %q: %w", diskName, err))
+			}
+			continue
+		}
+		if clone.Labels[api.GCPMachineNameKey] != sanitizeLabelValue(machineName) || len(clone.Users) != 0 {
+			continue
+		}
+		klog.V(3).Infof("Deleting cloned disk %q left over by machine %q", diskName, machineName)
+		if err := deleteDisk(ctx, computeService, project, zone, diskName); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete cloned disk %q: %w", diskName, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func getDisk(ctx context.Context, computeService *compute.Service, project, zone, diskName string) (_ *compute.Disk, err error) {
+	defer instrument.GcpAPIMetricRecorderFn(diskGetServiceLabel, &err)()
+	return computeService.Disks.Get(project, zone, diskName).Context(ctx).Do()
+}
+
+func deleteDisk(ctx context.Context, computeService *compute.Service, project, zone, diskName string) (err error) {
+	defer instrument.GcpAPIMetricRecorderFn(diskDeleteServiceLabel, &err)()
+	_, err = computeService.Disks.Delete(project, zone, diskName).Context(ctx).Do()
+	return err
+}
+
+// deleteClonedDisks deletes the given cloned disks on a best effort basis, errors are only logged
+func deleteClonedDisks(ctx context.Context, computeService *compute.Service, project, zone string, diskNames []string) {
+	for _, diskName := range diskNames {
+		if err := deleteDisk(ctx, computeService, project, zone, diskName); err != nil {
+			klog.Errorf("Failed to delete cloned disk %q: %v", diskName, err)
+		}
+	}
+}
+
+// clonedDiskName returns the name of the clone created for the disk at the given index
+func clonedDiskName(machineName string, index int) string {
+	return fmt.Sprintf("%s-disk-%d", machineName, index)
+}
+
+// machineIdentityLabels returns the GCE labels identifying the Machine, its namespace, MachineClass and MachineDeployment
+func machineIdentityLabels(machine *v1alpha1.Machine, machineClass *v1alpha1.MachineClass) map[string]string {
+	labels := map[string]string{
+		api.GCPMachineNameKey: sanitizeLabelValue(machine.Name),
+	}
+	if machine.Namespace != "" {
+		labels[api.GCPMachineNamespaceKey] = sanitizeLabelValue(machine.Namespace)
+	}
+	if machineClass != nil && machineClass.Name != "" {
+		labels[api.GCPMachineClassKey] = sanitizeLabelValue(machineClass.Name)
+	}
+	if machineDeployment := machineDeploymentName(machine); machineDeployment != "" {
+		labels[api.GCPMachineDeploymentKey] = sanitizeLabelValue(machineDeployment)
+	}
+	return labels
+}
+
+// appendMachineIdentityMetadata appends the unsanitized identity of the Machine to the metadata items,
+// keys already set in the provider spec are left untouched
+func appendMachineIdentityMetadata(items []*compute.MetadataItems, machine *v1alpha1.Machine, machineClass *v1alpha1.MachineClass) []*compute.MetadataItems {
+	identity := []struct{ key, value string }{
+		{api.GCPMachineNameKey, machine.Name},
+		{api.GCPMachineNamespaceKey, machine.Namespace},
+		{api.GCPMachineDeploymentKey, machineDeploymentName(machine)},
+	}
+	if machineClass != nil {
+		identity = append(identity, struct{ key, value string }{api.GCPMachineClassKey, machineClass.Name})
+	}
+	for _, item := range identity {
+		if item.value == "" || slices.ContainsFunc(items, func(existing *compute.MetadataItems) bool { return existing.Key == item.key }) {
+			continue
+		}
+		items = append(items, &compute.MetadataItems{
+			Key:   item.key,
+			Value: ptr.To(item.value),
+		})
+	}
+	return items
+}
+
+// machineDeploymentName derives the name of the MachineDeployment from the owning MachineSet, which is named
+// after the MachineDeployment suffixed with the machine template hash. It is empty if it cannot be derived.
+func machineDeploymentName(machine *v1alpha1.Machine) string {
+	hash := machine.Labels[v1alpha1.DefaultMachineDeploymentUniqueLabelKey]
+	if hash == "" {
+		return ""
+	}
+	for _, owner := range machine.OwnerReferences {
+		if owner.Kind == "MachineSet" && strings.HasSuffix(owner.Name, "-"+hash) {
+			return strings.TrimSuffix(owner.Name, "-"+hash)
+		}
+	}
+	return ""
+}
+
+// sanitizeLabelValue converts a Kubernetes object name into a valid GCE label value, i.e. at most 63 lowercase
+// letters, digits, dashes and underscores
+func sanitizeLabelValue(value string) string {
+	value = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '-'
+		}
+	}, value)
+	if len(value) > 63 {
+		value = value[:63]
+	}
+	return value
+}
+
+// sanitizeLabelsAndTags converts the labels of the instance and its disks and the network tags into valid GCE labels and tags
+func sanitizeLabelsAndTags(providerSpec *api.GCPProviderSpec) {
+	providerSpec.Labels = sanitizeLabels(providerSpec.Labels)
+	for _, disk := range providerSpec.Disks {
+		disk.Labels = sanitizeLabels(disk.Labels)
+	}
+
+	tags := make([]string, 0, len(providerSpec.Tags))
+	for _, tag := range providerSpec.Tags {
+		if tag = sanitizeTag(tag); !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	providerSpec.Tags = tags
+}
+
+// sanitizeLabels converts the labels into valid GCE labels, if two keys collide the first key in lexical order wins
+func sanitizeLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+	keys := slices.Sorted(maps.Keys(labels))
+	sanitized := make(map[string]string, len(labels))
+	for _, key := range keys {
+		sanitizedKey := sanitizeName(key)
+		if _, ok := sanitized[sanitizedKey]; ok {
+			klog.Warningf("Dropping label %q as it collides with another label after sanitization", key)
+			continue
+		}
+		sanitized[sanitizedKey] = sanitizeLabelValue(labels[key])
+	}
+	return sanitized
+}
+
+// sanitizeName converts a label key into a valid GCE label key starting with a lowercase letter
+func sanitizeName(name string) string {
+	name = strings.ToLower(name)
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = "x" + name
+	}
+	return sanitizeLabelValue(name)
+}
+
+// sanitizeTag converts a network tag into a valid RFC 1035 label
+func sanitizeTag(tag string) string {
+	return strings.TrimRight(strings.ReplaceAll(sanitizeName(tag), "_", "-"), "-")
+}
+
+// mergeLabels returns the union of both label maps, labels explicitly set in the provider spec take precedence
+func mergeLabels(identityLabels, labels map[string]string) map[string]string {
+	merged := make(map[string]string, len(identityLabels)+len(labels))
+	maps.Copy(merged, identityLabels)
+	maps.Copy(merged, labels)
+	return merged
+}
+
+// renderHostname renders the hostname template of the provider spec for the machine
+func renderHostname(providerSpec *api.GCPProviderSpec, machine *v1alpha1.Machine) (string, error) {
+	tmpl, err := template.New("hostname").Option("missingkey=error").Parse(providerSpec.Hostname)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse hostname template: %w", err)
+	}
+	var hostname strings.Builder
+	if err := tmpl.Execute(&hostname, map[string]string{
+		"MachineName":      machine.Name,
+		"MachineNamespace": machine.Namespace,
+		"Zone":             providerSpec.Zone,
+		"Region":           providerSpec.Region,
+	}); err != nil {
+		return "", fmt.Errorf("failed to render hostname template: %w", err)
+	}
+	if err := validation.ValidateHostname(hostname.String()); err != nil {
+		return "", fmt.Errorf("rendered hostname %q is invalid: %w", hostname.String(), err)
+	}
+	return hostname.String(), nil
+}
+
+// customMachineTypeName returns the machine type name of a custom machine type, e.g. n2-custom-8-32768
+func customMachineTypeName(customMachineType *api.GCPCustomMachineType) string {
+	name := fmt.Sprintf("custom-%d-%d", customMachineType.Cpus, customMachineType.MemoryMiB)
+	// n1 is the default series and has no prefix
+	if customMachineType.Series != "n1" {
+		name = customMachineType.Series + "-" + name
+	}
+	if customMachineType.ExtendedMemory {
+		name += "-ext"
+	}
+	return name
+}
+
+// instanceTemplateURL resolves an instance template name to a global template of the project, URLs are passed through unchanged
+func instanceTemplateURL(project, instanceTemplate string) string {
+	if strings.Contains(instanceTemplate, "/") {
+		return instanceTemplate
+	}
+	return fmt.Sprintf("projects/%s/global/instanceTemplates/%s", project, instanceTemplate)
+}
+
+// zonalDiskURL resolves a disk name against the zone, URLs are passed through unchanged
+func zonalDiskURL(zone, disk string) string {
+	if strings.Contains(disk, "/") {
+		return disk
+	}
+	return fmt.Sprintf("zones/%s/disks/%s", zone, disk)
+}
+
+// snapshotURL resolves a snapshot name to a global snapshot, URLs are passed through unchanged
+func snapshotURL(snapshot string) string {
+	if strings.Contains(snapshot, "/") {
+		return snapshot
+	}
+	return fmt.Sprintf("global/snapshots/%s", snapshot)
+}
+
+// resolveNatIP returns the IP of a reserved external address, IP literals are passed through unchanged
+func resolveNatIP(ctx context.Context, computeService *compute.Service, project, region, natIP string) (_ string, err error) {
+	if net.ParseIP(natIP) != nil {
+		return natIP, nil
+	}
+
+	defer instrument.GcpAPIMetricRecorderFn(addressGetServiceLabel, &err)()
+	address, err := computeService.Addresses.Get(project, region, natIP).Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve reserved external address %q: %w", natIP, err)
+	}
+	return address.Address, nil
+}
+
+// networkURL resolves a network name against the host project or the project, URLs are passed through unchanged
+func networkURL(project string, nic *api.GCPNetworkInterface) string {
+	if strings.Contains(nic.Network, "/") {
+		return nic.Network
+	}
+	if nic.HostProject != "" {
+		project = nic.HostProject
+	}
+	return fmt.Sprintf("projects/%s/global/networks/%s", project, nic.Network)
+}
+
+// subnetworkURL resolves a subnetwork name against the region and the host project, URLs are passed through unchanged
+func subnetworkURL(region string, nic *api.GCPNetworkInterface) string {
+	if strings.Contains(nic.Subnetwork, "/") {
+		return nic.Subnetwork
+	}
+	if nic.HostProject != "" {
+		return fmt.Sprintf("projects/%s/regions/%s/subnetworks/%s", nic.HostProject, region, nic.Subnetwork)
+	}
+	return fmt.Sprintf("regions/%s/subnetworks/%s", region, nic.Subnetwork)
+}
+
+// resourcePolicyURL resolves a short resource policy name against the region, URLs are passed through unchanged
+func resourcePolicyURL(project, region, resourcePolicy string) string {
+	if strings.Contains(resourcePolicy, "/") {
+		return resourcePolicy
+	}
+	return fmt.Sprintf("projects/%s/regions/%s/resourcePolicies/%s", project, region, resourcePolicy)
+}
+
 func encodeMachineID(project, zone, name string) string {
 	if name == "" {
 		return ""
@@ -228,6 +773,15 @@ func encodeMachineID(project, zone, name string) string {
 	return fmt.Sprintf("%s/%s/%s/%s", ProviderPrefix, project, zone, name)
 }
 
+// decodeMachineID returns the project, zone and name of the VM encoded in the machine ID by encodeMachineID
+func decodeMachineID(machineID string) (project, zone, name string, err error) {
+	parts := strings.Split(strings.TrimPrefix(machineID, ProviderPrefix+"/"), "/")
+	if !strings.HasPrefix(machineID, ProviderPrefix+"/") || len(parts) != 3 || slices.Contains(parts, "") {
+		return "", "", "", fmt.Errorf("invalid provider ID %q, expected %s/<project>/<zone>/<name>", machineID, ProviderPrefix)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
 // DeleteMachineUtil deletes a VM by name
 func (ms *MachinePlugin) DeleteMachineUtil(_ context.Context, machineName string, _ string, providerSpec *api.GCPProviderSpec, secret *corev1.Secret) (machineID string, err error) {
 	defer instrument.GcpAPIMetricRecorderFn(instanceDeleteServiceLabel, &err)()
@@ -243,17 +797,32 @@ func (ms *MachinePlugin) DeleteMachineUtil(_ context.Context, machineName string
 	}
 
 	zone := providerSpec.Zone
-
-	result, err := getVMs(ctx, machineName, providerSpec, secret, project, computeService)
-	if err != nil {
-		return "", err
-	} else if len(result) == 0 {
+	machineNotFound := func() (string, error) {
+		// the instance may have failed to be created after the source disks were cloned for it
+		if err := deleteLeftoverClonedDisks(ctx, computeService, project, zone, machineName, providerSpec.Disks); err != nil {
+			return "", err
+		}
 		return "", &errors2.MachineNotFoundError{Name: machineName}
 	}
 
+	// without the cluster and role tags the ownership cannot be checked, the instance is deleted by its name only
+	withOwnership := clusterOwnershipLabels(providerSpec.Tags) != nil
+	if withOwnership {
+		result, err := getVMs(ctx, machineName, providerSpec, secret, project, computeService)
+		if err != nil {
+			return "", err
+		}
+		if len(result) == 0 {
+			return machineNotFound()
+		}
+	}
+
 	operation, err := computeService.Instances.Delete(project, zone, machineName).Context(ctx).Do()
 	if err != nil {
 		if ae, ok := err.(*googleapi.Error); ok && ae.Code == http.StatusNotFound {
+			if !withOwnership {
+				return machineNotFound()
+			}
 			return "", nil
 		}
 		return "", err
@@ -262,8 +831,8 @@ func (ms *MachinePlugin) DeleteMachineUtil(_ context.Context, machineName string
 	return encodeMachineID(project, zone, machineName), WaitUntilOperationCompleted(computeService, project, zone, operation.Name)
 }
 
-// GetMachineStatusUtil checks for existence of VM by name
-func (ms *MachinePlugin) GetMachineStatusUtil(_ context.Context, machineName string, _ string, providerSpec *api.GCPProviderSpec, secret *corev1.Secret) (string, error) {
+// GetMachineStatusUtil checks for existence of VM by name or, if set, by the provider ID of the machine
+func (ms *MachinePlugin) GetMachineStatusUtil(_ context.Context, machineName string, providerID string, providerSpec *api.GCPProviderSpec, secret *corev1.Secret) (machineID string, err error) {
 	ctx, computeService, err := ms.SPI.NewComputeService(secret)
 	if err != nil {
 		return "", err
@@ -274,16 +843,130 @@ func (ms *MachinePlugin) GetMachineStatusUtil(_ context.Context, machineName str
 		return "", err
 	}
 	zone := providerSpec.Zone
+	instanceName := machineName
+	if providerID != "" {
+		if project, zone, instanceName, err = decodeMachineID(providerID); err != nil {
+			return "", err
+		}
+	}
 
-	result, err := getVMs(ctx, machineName, providerSpec, secret, project, computeService)
+	instance, err := getInstance(ctx, computeService, project, zone, instanceName)
 	if err != nil {
+		if ae, ok := err.(*googleapi.Error); ok && ae.Code == http.StatusNotFound {
+			return "", &errors2.MachineNotFoundError{Name: machineName}
+		}
 		return "", err
-	} else if len(result) == 0 {
-		// No running instance exists with the given machine-ID
+	}
+
+	// without the cluster and role tags the ownership cannot be checked, the instance is identified by its name only
+	ownershipLabels := clusterOwnershipLabels(providerSpec.Tags)
+	if ownershipLabels != nil && !isOwnedBy(instance, ownershipLabels) {
+		// the instance exists, but it does not belong to the cluster of the machine
+		klog.V(3).Infof("Instance %q is not owned by cluster %q with role %q", instanceName, ownershipLabels[api.GCPClusterNameKey], ownershipLabels[api.GCPNodeRoleKey])
 		return "", &errors2.MachineNotFoundError{Name: machineName}
 	}
 
-	return encodeMachineID(project, zone, machineName), nil
+	// the machine ID is returned for instances that are not running as well, as MCM uses it for uninitialized VMs
+	return encodeMachineID(project, zone, instance.Name), checkInstanceRunning(instance)
+}
+
+// getInstance gets the instance by name, only the API call is recorded. A missing instance is an expected answer, e.g.
+// for every machine before it is created, and is not recorded as a failed call.
+func getInstance(ctx context.Context, computeService *compute.Service, project, zone, instanceName string) (*compute.Instance, error) {
+	invocationTime := time.Now()
+	instance, err := computeService.Instances.Get(project, zone, instanceName).Context(ctx).Do()
+	metricErr := err
+	if ae, ok := err.(*googleapi.Error); ok && ae.Code == http.StatusNotFound {
+		metricErr = nil
+	}
+	instrument.RecordGcpAPIMetric(metricErr, instanceGetServiceLabel, invocationTime)
+	return instance, err
+}
+
+// checkInstanceRunning returns a MachineNotRunningError with the machine code matching the status of the instance,
+// if it is not running
+func checkInstanceRunning(instance *compute.Instance) error {
+	var (
+		code codes.Code
+		msg  string
+	)
+	switch instance.Status {
+	case instanceStatusRunning:
+		return nil
+	case instanceStatusProvisioning, instanceStatusStaging:
+		code, msg = codes.Uninitialized, "it is still booting"
+	case instanceStatusRepairing:
+		code, msg = codes.Uninitialized, "it is being repaired"
+	case instanceStatusStopping, instanceStatusSuspending:
+		code, msg = codes.Unavailable, "it is being stopped or suspended"
+	case instanceStatusStopped, instanceStatusSuspended:
+		code, msg = codes.FailedPrecondition, "it has to be started or resumed"
+	case instanceStatusTerminated, instanceStatusDeprovisioning:
+		code, msg = codes.Aborted, "it was stopped or preempted and has to be replaced"
+	default:
+		code, msg = codes.Unknown, "the status is unknown"
+	}
+	if instance.StatusMessage != "" {
+		msg = fmt.Sprintf("%s: %s", msg, instance.StatusMessage)
+	}
+	return &errors2.MachineNotRunningError{Name: instance.Name, Status: instance.Status, Code: code, Msg: msg}
+}
+
+// InitializeMachineUtil checks that the VM is RUNNING and returns the addresses it was assigned. The VM is not waited
+// for, a booting VM is reported as uninitialized so that MCM retries the initialization.
+func (ms *MachinePlugin) InitializeMachineUtil(_ context.Context, machineName string, providerID string, providerSpec *api.GCPProviderSpec, secret *corev1.Secret) (machineID string, addresses []corev1.NodeAddress, err error) {
+	ctx, computeService, err := ms.SPI.NewComputeService(secret)
+	if err != nil {
+		return "", nil, err
+	}
+
+	project, err := ExtractProject(secret.Data)
+	if err != nil {
+		return "", nil, err
+	}
+	zone := providerSpec.Zone
+	instanceName := machineName
+	if providerID != "" {
+		if project, zone, instanceName, err = decodeMachineID(providerID); err != nil {
+			return "", nil, err
+		}
+	}
+
+	instance, err := getInstance(ctx, computeService, project, zone, instanceName)
+	if err != nil {
+		if ae, ok := err.(*googleapi.Error); ok && ae.Code == http.StatusNotFound {
+			return "", nil, &errors2.MachineNotFoundError{Name: machineName}
+		}
+		return "", nil, err
+	}
+	// a VM that is still booting is reported with codes.Uninitialized, a VM that was stopped, suspended or
+	// preempted before it finished booting with the code of its status
+	if err := checkInstanceRunning(instance); err != nil {
+		return "", nil, err
+	}
+
+	return encodeMachineID(project, zone, instanceName), instanceAddresses(instance), nil
+}
+
+// instanceAddresses returns the internal and external IPv4 and IPv6 addresses of all network interfaces of the instance
+func instanceAddresses(instance *compute.Instance) []corev1.NodeAddress {
+	var addresses []corev1.NodeAddress
+	appendAddress := func(addressType corev1.NodeAddressType, address string) {
+		if address != "" {
+			addresses = append(addresses, corev1.NodeAddress{Type: addressType, Address: address})
+		}
+	}
+	for _, nic := range instance.NetworkInterfaces {
+		appendAddress(corev1.NodeInternalIP, nic.NetworkIP)
+		appendAddress(corev1.NodeInternalIP, nic.Ipv6Address)
+		for _, accessConfig := range nic.AccessConfigs {
+			appendAddress(corev1.NodeExternalIP, accessConfig.NatIP)
+		}
+		for _, accessConfig := range nic.Ipv6AccessConfigs {
+			appendAddress(corev1.NodeExternalIP, accessConfig.ExternalIpv6)
+		}
+	}
+	return addresses
 }
 
 // ListMachinesUtil lists all VMs in the DC or folder
@@ -311,41 +994,25 @@ func getVMs(ctx context.Context, machineID string, providerSpec *api.GCPProvider
 	defer instrument.GcpAPIMetricRecorderFn(instanceGetServiceLabel, &err)()
 	listOfVMs = make(map[string]string)
 
-	searchClusterName := ""
-	searchNodeRole := ""
-
-	for _, key := range providerSpec.Tags {
-		if strings.Contains(key, "kubernetes-io-cluster-") {
-			searchClusterName = key
-		} else if strings.Contains(key, "kubernetes-io-role-") {
-			searchNodeRole = key
-		}
-	}
-
-	if searchClusterName == "" || searchNodeRole == "" {
-		return listOfVMs, nil
+	clusterTag, nodeRoleTag := clusterAndRoleTags(providerSpec.Tags)
+	ownershipLabels := clusterOwnershipLabels(providerSpec.Tags)
+	if ownershipLabels == nil {
+		return nil, errMissingOwnershipTags
 	}
 
 	zone := providerSpec.Zone
 
-	req := computeService.Instances.List(project, zone)
+	// the instances are pre-selected server-side by their tags, which both labeled and legacy instances carry,
+	// the ownership is checked exactly below
+	req := computeService.Instances.List(project, zone).
+		Filter(instanceListFilter(clusterTag, nodeRoleTag, machineID)).
+		Fields(instanceListFields...)
 	pageNumber := 0
 	if err := req.Pages(ctx, func(page *compute.InstanceList) error {
 		pageNumber++
 		klog.V(3).Infof("Processing page %d with %d instances", pageNumber, len(page.Items))
 		for _, server := range page.Items {
-			clusterName := ""
-			nodeRole := ""
-
-			for _, key := range server.Tags.Items {
-				if strings.Contains(key, "kubernetes-io-cluster-") {
-					clusterName = key
-				} else if strings.Contains(key, "kubernetes-io-role-") {
-					nodeRole = key
-				}
-			}
-
-			if clusterName == searchClusterName && nodeRole == searchNodeRole {
+			if isOwnedBy(server, ownershipLabels) {
 				instanceID := server.Name
 
 				if machineID == "" {
@@ -366,6 +1033,69 @@ func getVMs(ctx context.Context, machineID string, providerSpec *api.GCPProvider
 	return listOfVMs, nil
 }
 
+// instanceListFilter returns the filter expression selecting the instances carrying the cluster and role tags and,
+// if set, the given name
+func instanceListFilter(clusterTag, nodeRoleTag, name string) string {
+	expressions := []string{
+		fmt.Sprintf("(tags.items = %q)", clusterTag),
+		fmt.Sprintf("(tags.items = %q)", nodeRoleTag),
+	}
+	if name != "" {
+		expressions = append(expressions, fmt.Sprintf("(name = %q)", name))
+	}
+	return strings.Join(expressions, " ")
+}
+
+// clusterAndRoleTags returns the network tags identifying the cluster and the role of a machine, a tag is left empty
+// unless there is exactly one tag with its prefix
+func clusterAndRoleTags(tags []string) (clusterTag, nodeRoleTag string) {
+	var clusterTags, nodeRoleTags []string
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, api.GCPClusterTagPrefix) {
+			clusterTags = append(clusterTags, tag)
+		} else if strings.HasPrefix(tag, api.GCPNodeRoleTagPrefix) {
+			nodeRoleTags = append(nodeRoleTags, tag)
+		}
+	}
+	if clusterTags = slices.Compact(slices.Sorted(slices.Values(clusterTags))); len(clusterTags) == 1 {
+		clusterTag = clusterTags[0]
+	}
+	if nodeRoleTags = slices.Compact(slices.Sorted(slices.Values(nodeRoleTags))); len(nodeRoleTags) == 1 {
+		nodeRoleTag = nodeRoleTags[0]
+	}
+	return clusterTag, nodeRoleTag
+}
+
+// clusterOwnershipLabels returns the labels identifying the cluster and the role of the machines carrying the network
+// tags, or nil if the cluster or the role tag is missing
+func clusterOwnershipLabels(tags []string) map[string]string {
+	clusterTag, nodeRoleTag := clusterAndRoleTags(tags)
+	if clusterTag == "" || nodeRoleTag == "" {
+		return nil
+	}
+	// network tags are RFC 1035 labels of at most 63 characters, so the names are valid label values as they are
+	return map[string]string{
+		api.GCPClusterNameKey: strings.TrimPrefix(clusterTag, api.GCPClusterTagPrefix),
+		api.GCPNodeRoleKey:    strings.TrimPrefix(nodeRoleTag, api.GCPNodeRoleTagPrefix),
+	}
+}
+
+// isOwnedBy reports whether the instance belongs to the cluster and role of the ownership labels. Instances created
+// before the ownership labels were introduced do not carry them and are matched by their network tags instead. The
+// machine class label is not matched, as MCM moves machines to another class on in-place updates and collects orphaned
+// VMs of the cluster through any of its classes.
+func isOwnedBy(instance *compute.Instance, ownershipLabels map[string]string) bool {
+	if _, labeled := instance.Labels[api.GCPClusterNameKey]; labeled {
+		return instance.Labels[api.GCPClusterNameKey] == ownershipLabels[api.GCPClusterNameKey] &&
+			instance.Labels[api.GCPNodeRoleKey] == ownershipLabels[api.GCPNodeRoleKey]
+	}
+	var instanceTags []string
+	if instance.Tags != nil {
+		instanceTags = instance.Tags.Items
+	}
+	return maps.Equal(clusterOwnershipLabels(instanceTags), ownershipLabels)
+}
+
 // decodeProviderSpec converts request parameters to api.ProviderSpec
 func decodeProviderSpec(machineClass *v1alpha1.MachineClass) (*api.GCPProviderSpec, error) {
 	var providerSpec *api.GCPProviderSpec
@@ -378,6 +1108,9 @@ func decodeProviderSpec(machineClass *v1alpha1.MachineClass) (*api.GCPProviderSp
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	if providerSpec != nil && providerSpec.SanitizeLabelsAndTags {
+		sanitizeLabelsAndTags(providerSpec)
+	}
 	return providerSpec, nil
 }
 
@@ -389,8 +1122,8 @@ func validateProviderSpec(providerSpec *api.GCPProviderSpec) error {
 	return nil
 }
 
-func validateSecret(secret *corev1.Secret) error {
-	validationErr := validation.ValidateSecret(secret)
+func validateSecret(secret *corev1.Secret, providerSpec *api.GCPProviderSpec) error {
+	validationErr := validation.ValidateSecret(secret, providerSpec)
 	if validationErr != nil {
 		err := fmt.Errorf("error while validating Secret %v", validationErr)
 		return status.Error(codes.Internal, err.Error())
@@ -418,6 +1151,9 @@ func prepareErrorf(err error, format string, args ...interface{}) error {
 	case *errors2.MachineResourceExhaustedError:
 		code = codes.ResourceExhausted
 		wrapped = errors.Wrap(err, fmt.Sprintf(format, args...))
+	case *errors2.MachineNotRunningError:
+		code = err.(*errors2.MachineNotRunningError).Code
+		wrapped = errors.Wrap(err, fmt.Sprintf(format, args...))
 	default:
 		code = codes.Internal
 		wrapped = errors.Wrap(err, fmt.Sprintf(format, args...))
@@ -489,7 +1225,7 @@ func getUserData(userData string) *compute.MetadataItems {
 func classifyIfResourceExhaustedError(err error) error {
 	gerr, ok := err.(*googleapi.Error)
 	// https://cloud.google.com/compute/docs/troubleshooting/troubleshooting-vm-creation#zone_availability also depends on error message, that's why adopted this approach
-	if ok && strings.Contains(gerr.Message, "does not exist in zone") {
+	if ok && (strings.Contains(gerr.Message, "does not exist in zone") || isReservationExhaustedMessage(gerr.Message)) {
 		return &errors2.MachineResourceExhaustedError{Msg: err.Error()}
 	}
 	return err
@@ -500,5 +1236,23 @@ func checkIfResourceExhaustedError(opErr *compute.OperationErrorErrors, errorMes
 	if opErr.Code == "RESOURCE_POOL_EXHAUSTED" || opErr.Code == "ZONE_RESOURCE_POOL_EXHAUSTED" || opErr.Code == "ZONE_RESOURCE_POOL_EXHAUSTED_WITH_DETAILS" || strings.Contains(opErr.Code, "QUOTA") {
 		return &errors2.MachineResourceExhaustedError{Msg: combinedErrMsg}
 	}
+	// exhausted reservations and placement policies are reported with generic error codes, so the message has to be checked
+	if isReservationExhaustedMessage(opErr.Message) || isPlacementPolicyExhaustedMessage(opErr.Message) {
+		return &errors2.MachineResourceExhaustedError{Msg: combinedErrMsg}
+	}
 	return fmt.Errorf("%s", combinedErrMsg)
 }
+
+// isPlacementPolicyExhaustedMessage checks whether the instance could not be placed because a compact placement policy
+// has no capacity left. Other errors of a placement policy, e.g. an unsupported machine type, are not retried.
+func isPlacementPolicyExhaustedMessage(msg string) bool {
+	msg = strings.ToLower(msg)
+	return strings.Contains(msg, "placement policy") &&
+		(strings.Contains(msg, "insufficient capacity") || strings.Contains(msg, "does not have sufficient resources") || strings.Contains(msg, "does not have enough resources"))
+}
+
+func isReservationExhaustedMessage(msg string) bool {
+	msg = strings.ToLower(msg)
+	return strings.Contains(msg, "reservation") &&
+		(strings.Contains(msg, "does not have available resources") || strings.Contains(msg, "does not have sufficient resources") || strings.Contains(msg, "insufficient capacity"))
+}
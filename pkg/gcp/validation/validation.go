@@ -5,24 +5,211 @@
 package validation
 
 import (
+	"cmp"
+	"encoding/base64"
 	"fmt"
+	"maps"
+	"net"
+	"reflect"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	api "github.com/gardener/machine-controller-manager-provider-gcp/pkg/api/v1alpha1"
 )
 
+// confidentialInstanceTypeSeries maps each confidential instance type to the machine series supporting it
+var confidentialInstanceTypeSeries = map[string][]string{
+	api.GCPConfidentialInstanceTypeSEV:    {"n2d", "c2d", "c3d", "c4d"},
+	api.GCPConfidentialInstanceTypeSEVSNP: {"n2d"},
+	api.GCPConfidentialInstanceTypeTDX:    {"c3"},
+}
+
+// reservationValueRegex matches a reservation name or a shared reservation of another project
+var reservationValueRegex = regexp.MustCompile(`^(projects/[a-z][-a-z0-9.:]*[a-z0-9]/reservations/)?[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+var (
+	// projectIDRegex matches a GCP project ID, including domain-scoped project IDs
+	projectIDRegex = regexp.MustCompile(`^([a-z][-a-z0-9.]*[a-z0-9]:)?[a-z][-a-z0-9]{4,28}[a-z0-9]$`)
+	// networkURLRegex matches a full or partial network URL
+	networkURLRegex = regexp.MustCompile(`^(https://www\.googleapis\.com/compute/v1/)?projects/[^/]+/global/networks/[^/]+$`)
+	// subnetworkURLRegex matches a full or partial subnetwork URL
+	subnetworkURLRegex = regexp.MustCompile(`^(https://www\.googleapis\.com/compute/v1/)?projects/[^/]+/regions/[^/]+/subnetworks/[^/]+$`)
+)
+
+// instanceTemplateRegex matches an instance template name or a full or partial global or regional instance template URL
+var instanceTemplateRegex = regexp.MustCompile(`^((https://www\.googleapis\.com/compute/v1/)?projects/[^/]+/(global|regions/[^/]+)/instanceTemplates/|global/instanceTemplates/)?[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+var (
+	// resourceManagerTagKeyRegex matches the ID of a Resource Manager tag key
+	resourceManagerTagKeyRegex = regexp.MustCompile(`^tagKeys/[0-9]+$`)
+	// resourceManagerTagValueRegex matches the ID of a Resource Manager tag value
+	resourceManagerTagValueRegex = regexp.MustCompile(`^tagValues/[0-9]+$`)
+)
+
+var (
+	// labelKeyRegex matches a GCE label key
+	labelKeyRegex = regexp.MustCompile(`^[\p{Ll}\p{Lo}][\p{Ll}\p{Lo}\p{N}_-]{0,62}$`)
+	// labelValueRegex matches a GCE label value
+	labelValueRegex = regexp.MustCompile(`^[\p{Ll}\p{Lo}\p{N}_-]{0,63}$`)
+	// networkTagRegex matches a GCE network tag, which must be a RFC 1035 label
+	networkTagRegex = regexp.MustCompile(`^[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
+)
+
+const (
+	// maxLabels is the maximum number of labels of a GCE resource
+	maxLabels = 64
+	// reservedIdentityLabels is the number of labels reserved for the identity of the Machine and its cluster
+	reservedIdentityLabels = 6
+	// maxNetworkTags is the maximum number of network tags of an instance
+	maxNetworkTags = 64
+)
+
+// resourcePolicyRegex matches a resource policy name or a full or partial resource policy URL
+var resourcePolicyRegex = regexp.MustCompile(`^((https://www\.googleapis\.com/compute/v1/)?projects/[^/]+/regions/[^/]+/resourcePolicies/)?[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+// provisionedLimits describes the range of provisioned IOPS or throughput of a disk type
+type provisionedLimits struct {
+	min, max int64
+	// maxPerGiB bounds the value relative to the disk size, 0 if only bound by max
+	maxPerGiB float64
+}
+
+// diskCapabilities describes which optional parameters a disk type supports
+type diskCapabilities struct {
+	// iops and throughput are nil if they cannot be provisioned
+	iops, throughput *provisionedLimits
+	// regional reports whether the disk type can be replicated to two zones
+	regional bool
+}
+
+// diskTypeCapabilities maps the known persistent disk types to their capabilities, unknown types are not validated
+var diskTypeCapabilities = map[string]diskCapabilities{
+	"pd-standard": {regional: true},
+	"pd-balanced": {regional: true},
+	"pd-ssd":      {regional: true},
+	"pd-extreme": {
+		iops: &provisionedLimits{min: 10000, max: 120000},
+	},
+	"hyperdisk-balanced": {
+		iops:       &provisionedLimits{min: 3000, max: 160000, maxPerGiB: 500},
+		throughput: &provisionedLimits{min: 140, max: 2400},
+	},
+	"hyperdisk-balanced-high-availability": {
+		iops:       &provisionedLimits{min: 3000, max: 100000, maxPerGiB: 500},
+		throughput: &provisionedLimits{min: 140, max: 1200},
+		regional:   true,
+	},
+	"hyperdisk-extreme": {
+		iops: &provisionedLimits{min: 2500, max: 350000, maxPerGiB: 1000},
+	},
+	"hyperdisk-throughput": {
+		throughput: &provisionedLimits{min: 10, max: 600, maxPerGiB: 90.0 / 1024},
+	},
+	"hyperdisk-ml": {
+		throughput: &provisionedLimits{min: 400, max: 1200000},
+	},
+}
+
+// customMachineSeries describes the vCPU and memory constraints of custom machine types of a series
+type customMachineSeries struct {
+	// validCpus reports whether the number of vCPUs is supported
+	validCpus func(cpus int64) bool
+	// cpusHint describes the supported number of vCPUs
+	cpusHint string
+	// minMemoryPerCPU and maxMemoryPerCPU bound the memory per vCPU in MiB
+	minMemoryPerCPU, maxMemoryPerCPU float64
+	// maxMemory bounds the total memory in MiB, 0 if only bound per vCPU
+	maxMemory int64
+	// maxExtendedMemory bounds the total memory in MiB with extended memory, 0 if extended memory is not supported
+	maxExtendedMemory int64
+}
+
+// customMachineTypeSeries maps each machine series to the constraints of its custom machine types
+var customMachineTypeSeries = map[string]customMachineSeries{
+	"n1": {
+		validCpus: func(cpus int64) bool {
+			return cpus == 1 || cpus%2 == 0 && cpus >= 2 && cpus <= 96
+		},
+		cpusHint:          "1 or an even number up to 96",
+		minMemoryPerCPU:   0.9 * 1024,
+		maxMemoryPerCPU:   6.5 * 1024,
+		maxExtendedMemory: 624 * 1024,
+	},
+	"n2": {
+		validCpus: func(cpus int64) bool {
+			return cpus >= 2 && (cpus <= 30 && cpus%2 == 0 || cpus <= 80 && cpus%4 == 0 || cpus <= 128 && cpus%8 == 0)
+		},
+		cpusHint:          "a multiple of 2 up to 30, a multiple of 4 up to 80 or a multiple of 8 up to 128",
+		minMemoryPerCPU:   0.5 * 1024,
+		maxMemoryPerCPU:   8 * 1024,
+		maxExtendedMemory: 864 * 1024,
+	},
+	"n2d": {
+		validCpus: func(cpus int64) bool {
+			return cpus == 2 || cpus == 4 || cpus == 8 || cpus%16 == 0 && cpus >= 16 && cpus <= 96
+		},
+		cpusHint:          "2, 4, 8 or a multiple of 16 up to 96",
+		minMemoryPerCPU:   0.5 * 1024,
+		maxMemoryPerCPU:   8 * 1024,
+		maxExtendedMemory: 768 * 1024,
+	},
+	"n4": {
+		validCpus: func(cpus int64) bool {
+			return cpus >= 2 && (cpus <= 16 && cpus%2 == 0 || cpus <= 80 && cpus%4 == 0)
+		},
+		cpusHint:        "a multiple of 2 up to 16 or a multiple of 4 up to 80",
+		minMemoryPerCPU: 2 * 1024,
+		maxMemoryPerCPU: 8 * 1024,
+	},
+	"e2": {
+		validCpus: func(cpus int64) bool {
+			return cpus%2 == 0 && cpus >= 2 && cpus <= 32
+		},
+		cpusHint:        "an even number from 2 up to 32",
+		minMemoryPerCPU: 0.5 * 1024,
+		maxMemoryPerCPU: 8 * 1024,
+		maxMemory:       128 * 1024,
+	},
+}
+
+const (
+	// minMaxRunDuration and maxMaxRunDuration are the bounds GCE accepts for scheduling.maxRunDuration
+	minMaxRunDuration = 30 * time.Second
+	maxMaxRunDuration = 120 * 24 * time.Hour
+)
+
 // ValidateProviderSpec validates gcp provider spec
 func ValidateProviderSpec(spec *api.GCPProviderSpec) []error {
 	fldPath := field.NewPath("spec")
 	var allErrs []error
 
-	allErrs = append(allErrs, validateGCPDisks(spec.Disks, fldPath.Child("disks"))...)
+	// disks, machineType and network interfaces may be taken from the instance template
+	withTemplate := spec.SourceInstanceTemplate != ""
+	if withTemplate && !instanceTemplateRegex.MatchString(spec.SourceInstanceTemplate) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sourceInstanceTemplate"), spec.SourceInstanceTemplate, "must be an instance template name or a URL of the form projects/<project>/global/instanceTemplates/<name> or projects/<project>/regions/<region>/instanceTemplates/<name>"))
+	}
 
-	if spec.MachineType == "" {
+	if len(spec.Disks) == 0 && !withTemplate {
+		allErrs = append(allErrs, field.Required(fldPath.Child("disks"), "at least one disk is required"))
+	}
+	allErrs = append(allErrs, validateGCPDisks(spec.Disks, spec.Zone, spec.Region, fldPath.Child("disks"))...)
+
+	machineType := spec.MachineType
+	if spec.CustomMachineType != nil {
+		if spec.MachineType != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("customMachineType"), "customMachineType cannot be combined with machineType"))
+		}
+		allErrs = append(allErrs, validateGCPCustomMachineType(spec.CustomMachineType, fldPath.Child("customMachineType"))...)
+		machineType = spec.CustomMachineType.Series + "-custom"
+	} else if spec.MachineType == "" && !withTemplate {
 		allErrs = append(allErrs, field.Required(fldPath.Child("machineType"), "machineType is required"))
 	}
 	if spec.Region == "" {
@@ -32,10 +219,23 @@ func ValidateProviderSpec(spec *api.GCPProviderSpec) []error {
 		allErrs = append(allErrs, field.Required(fldPath.Child("zone"), "zone is required"))
 	}
 
-	allErrs = append(allErrs, validateGCPNetworkInterfaces(spec.NetworkInterfaces, fldPath.Child("networkInterfaces"))...)
+	if len(spec.NetworkInterfaces) == 0 && !withTemplate {
+		allErrs = append(allErrs, field.Required(fldPath.Child("networkInterfaces"), "at least one network interface is required"))
+	}
+	allErrs = append(allErrs, validateGCPNetworkInterfaces(spec.NetworkInterfaces, spec.NetworkPerformanceConfig, fldPath.Child("networkInterfaces"))...)
 	allErrs = append(allErrs, validateGCPMetadata(spec.Metadata, fldPath.Child("networkInterfaces"))...)
 	allErrs = append(allErrs, validateGCPGpu(spec.Gpu, fldPath.Child("gpu"))...)
-	allErrs = append(allErrs, validateGCPScheduling(spec.Scheduling, spec.Gpu, fldPath.Child("scheduling"))...)
+	if !withTemplate || !reflect.DeepEqual(spec.Scheduling, api.GCPScheduling{}) {
+		allErrs = append(allErrs, validateGCPScheduling(spec.Scheduling, spec.Gpu, fldPath.Child("scheduling"))...)
+	}
+	allErrs = append(allErrs, validateGCPReservationAffinity(spec.ReservationAffinity, fldPath.Child("reservationAffinity"))...)
+	allErrs = append(allErrs, validateResourcePolicies(spec.ResourcePolicies, fldPath.Child("resourcePolicies"))...)
+	allErrs = append(allErrs, validateHostnameTemplate(spec, fldPath.Child("hostname"))...)
+	allErrs = append(allErrs, validateGCPLabels(spec.Labels, fldPath.Child("labels"))...)
+	allErrs = append(allErrs, validateGCPNetworkTags(spec.Tags, fldPath.Child("tags"))...)
+	allErrs = append(allErrs, validateResourceManagerTags(spec.ResourceManagerTags, fldPath.Child("resourceManagerTags"))...)
+	allErrs = append(allErrs, validateAdvancedMachineFeatures(spec.AdvancedMachineFeatures, spec.CustomMachineType, fldPath.Child("advancedMachineFeatures"))...)
+	allErrs = append(allErrs, validateConfidentialInstanceConfig(spec.ConfidentialInstanceConfig, machineType, spec.Scheduling, fldPath.Child("confidentialInstanceConfig"))...)
 
 	return allErrs
 }
@@ -48,8 +248,26 @@ func ValidateZone(zone string) error {
 	return nil
 }
 
-// ValidateSecret validates the machine class secret
-func ValidateSecret(secret *corev1.Secret) []error {
+// ValidateHostname validates that the hostname is a RFC 1035 compliant FQDN as required by GCE
+func ValidateHostname(hostname string) error {
+	if len(hostname) > 253 {
+		return fmt.Errorf("hostname must be at most 253 characters")
+	}
+	labels := strings.Split(hostname, ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("hostname must consist of at least two labels")
+	}
+	for _, label := range labels {
+		if msgs := validation.IsDNS1035Label(label); len(msgs) != 0 {
+			return fmt.Errorf("hostname label %q is invalid: %s", label, strings.Join(msgs, ", "))
+		}
+	}
+	return nil
+}
+
+// ValidateSecret validates the machine class secret. If spec is not nil, the customer-supplied encryption keys
+// referenced by its disks must be present in the secret as well.
+func ValidateSecret(secret *corev1.Secret, spec *api.GCPProviderSpec) []error {
 	var allErrs []error
 
 	if secret == nil {
@@ -66,31 +284,91 @@ func ValidateSecret(secret *corev1.Secret) []error {
 		if !userDataExists {
 			allErrs = append(allErrs, fmt.Errorf("secret userData is required field"))
 		}
+		if spec != nil {
+			allErrs = append(allErrs, validateCustomerSuppliedKeysInSecret(secret, spec.Disks)...)
+		}
 	}
 
 	return allErrs
 }
 
-func validateGCPDisks(disks []*api.GCPDisk, fldPath *field.Path) []error {
+// validateCustomerSuppliedKeysInSecret checks that the customer-supplied keys referenced by the disks are well-formed
+// entries of the secret. The key material must never be part of an error message.
+func validateCustomerSuppliedKeysInSecret(secret *corev1.Secret, disks []*api.GCPDisk) []error {
 	var allErrs []error
 
-	if len(disks) == 0 {
-		allErrs = append(allErrs, field.Required(fldPath, "at least one disk is required"))
+	for _, disk := range disks {
+		if disk == nil || disk.Encryption == nil {
+			continue
+		}
+		for _, key := range []*api.GCPCustomerSuppliedKey{disk.Encryption.CustomerSuppliedKey, disk.Encryption.SourceImageKey} {
+			if key == nil {
+				continue
+			}
+			if key.RawKey != "" {
+				allErrs = append(allErrs, validateCustomerSuppliedKeyInSecret(secret, key.RawKey, 32)...)
+			}
+			if key.RsaEncryptedKey != "" {
+				allErrs = append(allErrs, validateCustomerSuppliedKeyInSecret(secret, key.RsaEncryptedKey, 0)...)
+			}
+		}
 	}
 
+	return allErrs
+}
+
+// validateCustomerSuppliedKeyInSecret checks that the secret entry name holds a base64 encoded key of the given
+// length in bytes, any length is accepted if it is 0
+func validateCustomerSuppliedKeyInSecret(secret *corev1.Secret, name string, length int) []error {
+	var allErrs []error
+
+	encoded, ok := secret.Data[name]
+	if !ok || len(strings.TrimSpace(string(encoded))) == 0 {
+		return append(allErrs, fmt.Errorf("secret %s is required field for the customer-supplied encryption key", name))
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		allErrs = append(allErrs, fmt.Errorf("secret %s must be base64 encoded", name))
+	} else if length != 0 && len(decoded) != length {
+		allErrs = append(allErrs, fmt.Errorf("secret %s must hold a %d-bit key", name, length*8))
+	}
+
+	return allErrs
+}
+
+func validateGCPDisks(disks []*api.GCPDisk, zone, region string, fldPath *field.Path) []error {
+	var allErrs []error
+
 	for i, disk := range disks {
 		idxPath := fldPath.Index(i)
 		if disk.Type == api.GCPDiskTypeScratch && (disk.Interface != api.GCPDiskInterfaceNVME && disk.Interface != api.GCPDiskInterfaceSCSI) {
 			allErrs = append(allErrs, field.NotSupported(idxPath.Child("interface"), disk.Interface, []string{api.GCPDiskInterfaceNVME, api.GCPDiskInterfaceSCSI}))
 		}
-		if disk.Boot && disk.Image == "" {
+		if disk.Boot && disk.Image == "" && disk.Source == "" && disk.SourceSnapshot == "" && disk.SourceDisk == "" {
 			allErrs = append(allErrs, field.Required(idxPath.Child("image"), "image is required for boot disk"))
 		}
+		allErrs = append(allErrs, validateGCPDiskSource(disk, idxPath)...)
+		allErrs = append(allErrs, validateGCPDiskCapabilities(disk, zone, region, idxPath)...)
+		allErrs = append(allErrs, validateGCPLabels(disk.Labels, idxPath.Child("labels"))...)
 		if disk.Encryption != nil {
 			kmsKeyName := strings.TrimSpace(disk.Encryption.KmsKeyName)
 			kmsKeyServiceAccount := strings.TrimSpace(disk.Encryption.KmsKeyServiceAccount)
-			if kmsKeyName == "" {
+			switch {
+			case disk.Encryption.CustomerSuppliedKey != nil:
+				if kmsKeyName != "" {
+					allErrs = append(allErrs, field.Forbidden(idxPath.Child("customerSuppliedKey"), "customerSuppliedKey cannot be combined with kmsKeyName"))
+				}
+				allErrs = append(allErrs, validateCustomerSuppliedKey(disk.Encryption.CustomerSuppliedKey, idxPath.Child("customerSuppliedKey"))...)
+			case kmsKeyName == "" && disk.Encryption.SourceImageKey == nil:
 				allErrs = append(allErrs, field.Required(idxPath.Child("kmsKeyName"), "kmsKeyName is required to be specified"))
+			case kmsKeyName == "" && kmsKeyServiceAccount != "":
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("kmsKeyServiceAccount"), "kmsKeyServiceAccount requires kmsKeyName"))
+			}
+			if disk.Encryption.SourceImageKey != nil {
+				if disk.Image == "" {
+					allErrs = append(allErrs, field.Forbidden(idxPath.Child("sourceImageKey"), "sourceImageKey is only supported for disks created from an image"))
+				}
+				allErrs = append(allErrs, validateCustomerSuppliedKey(disk.Encryption.SourceImageKey, idxPath.Child("sourceImageKey"))...)
 			}
 			// to deal with situation where  just spaces have been specified for `kmsKeyServiceAccount`
 			if disk.Encryption.KmsKeyServiceAccount != "" && kmsKeyServiceAccount == "" {
@@ -102,11 +380,224 @@ func validateGCPDisks(disks []*api.GCPDisk, fldPath *field.Path) []error {
 	return allErrs
 }
 
-func validateGCPNetworkInterfaces(interfaces []*api.GCPNetworkInterface, fldPath *field.Path) []error {
+func validateCustomerSuppliedKey(key *api.GCPCustomerSuppliedKey, fldPath *field.Path) []error {
 	var allErrs []error
 
-	if len(interfaces) == 0 {
-		allErrs = append(allErrs, field.Required(fldPath.Child("networkInterfaces"), "at least one network interface is required"))
+	if (key.RawKey == "") == (key.RsaEncryptedKey == "") {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "exactly one of rawKey and rsaEncryptedKey must be set"))
+	}
+
+	return allErrs
+}
+
+func validateGCPDiskCapabilities(disk *api.GCPDisk, zone, region string, fldPath *field.Path) []error {
+	var allErrs []error
+
+	if disk.Type == api.GCPDiskTypeScratch {
+		return allErrs
+	}
+	capabilities, known := diskTypeCapabilities[disk.Type]
+
+	if disk.ProvisionedIops != 0 && known {
+		allErrs = append(allErrs, validateProvisionedPerformance(capabilities.iops, disk.ProvisionedIops, disk, fldPath.Child("provisionedIops"))...)
+	}
+	if disk.ProvisionedThroughput != 0 && known {
+		allErrs = append(allErrs, validateProvisionedPerformance(capabilities.throughput, disk.ProvisionedThroughput, disk, fldPath.Child("provisionedThroughput"))...)
+	}
+	if disk.StoragePool != nil && !strings.HasPrefix(disk.Type, "hyperdisk-") {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("storagePool"), "storagePool is only supported for Hyperdisk types"))
+	}
+
+	if len(disk.ReplicaZones) != 0 {
+		switch {
+		case known && !capabilities.regional:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("replicaZones"), fmt.Sprintf("disk type %s does not support regional disks", disk.Type)))
+		case disk.Source != "" || disk.SourceDisk != "":
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("replicaZones"), "replicaZones cannot be combined with source or sourceDisk"))
+		case len(disk.ReplicaZones) != 2 || disk.ReplicaZones[0] == disk.ReplicaZones[1]:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("replicaZones"), disk.ReplicaZones, "must be exactly two different zones"))
+		case !slices.Contains(disk.ReplicaZones, zone):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("replicaZones"), disk.ReplicaZones, fmt.Sprintf("must contain the zone %s of the instance", zone)))
+		}
+		for i, replicaZone := range disk.ReplicaZones {
+			if !strings.HasPrefix(replicaZone, region+"-") {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("replicaZones").Index(i), replicaZone, fmt.Sprintf("must be a zone of the region %s", region)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateProvisionedPerformance(limits *provisionedLimits, value int64, disk *api.GCPDisk, fldPath *field.Path) []error {
+	var allErrs []error
+
+	if limits == nil {
+		return append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("not supported by disk type %s", disk.Type)))
+	}
+
+	maxValue := limits.max
+	if limits.maxPerGiB != 0 && disk.SizeGb != 0 {
+		maxValue = min(maxValue, int64(limits.maxPerGiB*float64(disk.SizeGb)))
+	}
+	if value < limits.min || value > maxValue {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be between %d and %d for disk type %s with %d GiB", limits.min, maxValue, disk.Type, disk.SizeGb)))
+	}
+
+	return allErrs
+}
+
+func validateGCPDiskSource(disk *api.GCPDisk, fldPath *field.Path) []error {
+	var allErrs []error
+
+	if len(disk.ResourceManagerTags) != 0 && (disk.Type == api.GCPDiskTypeScratch || disk.Source != "") {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("resourceManagerTags"), "resourceManagerTags are only supported for new persistent disks"))
+	}
+	allErrs = append(allErrs, validateResourceManagerTags(disk.ResourceManagerTags, fldPath.Child("resourceManagerTags"))...)
+
+	if disk.Type == api.GCPDiskTypeScratch {
+		if disk.Source != "" || disk.SourceSnapshot != "" || disk.SourceDisk != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "source, sourceSnapshot and sourceDisk cannot be set for SCRATCH disks"))
+		}
+		if disk.Mode != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("mode"), "mode cannot be set for SCRATCH disks"))
+		}
+		return allErrs
+	}
+
+	switch disk.Mode {
+	case "", api.GCPDiskModeReadWrite:
+	case api.GCPDiskModeReadOnly:
+		if disk.Source == "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("mode"), "READ_ONLY mode is only supported for existing disks referenced by source"))
+		}
+		if disk.Boot {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("mode"), "boot disk cannot be attached in READ_ONLY mode"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), disk.Mode, []string{api.GCPDiskModeReadOnly, api.GCPDiskModeReadWrite}))
+	}
+
+	if disk.Source != "" {
+		if disk.Image != "" || disk.SourceSnapshot != "" || disk.SourceDisk != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("source"), "source cannot be combined with image, sourceSnapshot or sourceDisk"))
+		}
+		if disk.SizeGb != 0 || len(disk.Labels) != 0 || disk.ProvisionedIops != 0 || disk.ProvisionedThroughput != 0 || disk.StoragePool != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("source"), "sizeGb, labels, provisionedIops, provisionedThroughput and storagePool cannot be set for existing disks"))
+		}
+		return allErrs
+	}
+
+	if disk.Image != "" && disk.SourceSnapshot != "" || disk.Image != "" && disk.SourceDisk != "" || disk.SourceSnapshot != "" && disk.SourceDisk != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only one of image, sourceSnapshot and sourceDisk can be set"))
+	}
+
+	return allErrs
+}
+
+func validateHostnameTemplate(spec *api.GCPProviderSpec, fldPath *field.Path) []error {
+	var allErrs []error
+
+	if spec.Hostname == "" {
+		return allErrs
+	}
+
+	tmpl, err := template.New("hostname").Option("missingkey=error").Parse(spec.Hostname)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, spec.Hostname, err.Error()))
+	}
+	// render the template with a sample machine, the actual hostname is validated again on creation
+	var hostname strings.Builder
+	if err := tmpl.Execute(&hostname, map[string]string{
+		"MachineName":      "machine",
+		"MachineNamespace": "namespace",
+		"Zone":             spec.Zone,
+		"Region":           spec.Region,
+	}); err != nil {
+		return append(allErrs, field.Invalid(fldPath, spec.Hostname, err.Error()))
+	}
+	if err := ValidateHostname(hostname.String()); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, spec.Hostname, err.Error()))
+	}
+
+	return allErrs
+}
+
+func validateGCPLabels(labels map[string]string, fldPath *field.Path) []error {
+	var allErrs []error
+
+	if len(labels) > maxLabels-reservedIdentityLabels {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(labels), maxLabels-reservedIdentityLabels))
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		if key == api.GCPClusterNameKey || key == api.GCPNodeRoleKey {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Key(key), "label is reserved to identify the cluster of the machine"))
+		}
+		if !labelKeyRegex.MatchString(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath, key, "key must start with a lowercase letter and consist of at most 63 lowercase letters, digits, underscores and dashes"))
+		}
+		if value := labels[key]; !labelValueRegex.MatchString(value) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), value, "value must consist of at most 63 lowercase letters, digits, underscores and dashes"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateGCPNetworkTags(tags []string, fldPath *field.Path) []error {
+	var allErrs []error
+
+	if len(tags) > maxNetworkTags {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(tags), maxNetworkTags))
+	}
+
+	var clusterTags, nodeRoleTags int
+	for i, tag := range tags {
+		if !networkTagRegex.MatchString(tag) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), tag, "must start with a lowercase letter, end with a lowercase letter or digit and consist of at most 63 lowercase letters, digits and dashes"))
+		}
+		if strings.HasPrefix(tag, api.GCPClusterTagPrefix) {
+			if clusterTags++; clusterTags > 1 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i), tag, fmt.Sprintf("only one tag with prefix %q is allowed", api.GCPClusterTagPrefix)))
+			}
+		} else if strings.HasPrefix(tag, api.GCPNodeRoleTagPrefix) {
+			if nodeRoleTags++; nodeRoleTags > 1 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i), tag, fmt.Sprintf("only one tag with prefix %q is allowed", api.GCPNodeRoleTagPrefix)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateResourceManagerTags(tags map[string]string, fldPath *field.Path) []error {
+	var allErrs []error
+
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		if !resourceManagerTagKeyRegex.MatchString(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath, key, "key must be of the form tagKeys/{tag_key_id}"))
+		}
+		if value := tags[key]; !resourceManagerTagValueRegex.MatchString(value) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), value, "value must be of the form tagValues/{tag_value_id}"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateGCPNetworkInterfaces(interfaces []*api.GCPNetworkInterface, networkPerformanceConfig *api.GCPNetworkPerformanceConfig, fldPath *field.Path) []error {
+	var allErrs []error
+
+	tier1 := false
+	if networkPerformanceConfig != nil {
+		switch networkPerformanceConfig.TotalEgressBandwidthTier {
+		case api.GCPEgressBandwidthTierDefault:
+		case api.GCPEgressBandwidthTierTier1:
+			tier1 = true
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Root().Child("networkPerformanceConfig", "totalEgressBandwidthTier"), networkPerformanceConfig.TotalEgressBandwidthTier, []string{api.GCPEgressBandwidthTierDefault, api.GCPEgressBandwidthTierTier1}))
+		}
 	}
 
 	for i, nic := range interfaces {
@@ -116,11 +607,40 @@ func validateGCPNetworkInterfaces(interfaces []*api.GCPNetworkInterface, fldPath
 		if nic.Network == "" && nic.Subnetwork == "" {
 			allErrs = append(allErrs, field.Required(idxPath, "either network or subnetwork or both is required"))
 		}
+		if strings.Contains(nic.Network, "/") && !networkURLRegex.MatchString(nic.Network) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("network"), nic.Network, "must be a network name or a URL of the form projects/<project>/global/networks/<name>"))
+		}
+		if strings.Contains(nic.Subnetwork, "/") && !subnetworkURLRegex.MatchString(nic.Subnetwork) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("subnetwork"), nic.Subnetwork, "must be a subnetwork name or a URL of the form projects/<project>/regions/<region>/subnetworks/<name>"))
+		}
+		if nic.HostProject != "" {
+			if !projectIDRegex.MatchString(nic.HostProject) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("hostProject"), nic.HostProject, "must be a valid project ID"))
+			}
+			if strings.Contains(nic.Network, "/") || strings.Contains(nic.Subnetwork, "/") {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("hostProject"), "hostProject cannot be combined with a network or subnetwork URL"))
+			}
+		}
 
+		ipv6Only := nic.StackType == api.GCPStackTypeIPv6Only
 		switch nic.StackType {
-		case "", "IPV4_IPV6", "IPV4_ONLY":
+		case "", api.GCPStackTypeIPv4IPv6, api.GCPStackTypeIPv4Only, api.GCPStackTypeIPv6Only:
 		default:
-			allErrs = append(allErrs, field.Invalid(idxPath.Child("stackType"), nic.StackType, "must be either IPV4_IPV6 or IPV4_ONLY"))
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("stackType"), nic.StackType, "must be one of IPV4_IPV6, IPV4_ONLY or IPV6_ONLY"))
+		}
+		if ipv6Only {
+			if nic.NetworkIP != "" {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("networkIP"), "networkIP cannot be set on an IPV6_ONLY interface"))
+			}
+			if nic.NatIP != "" {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("natIP"), "natIP cannot be set on an IPV6_ONLY interface"))
+			}
+			if nic.IpCidrRange != "" {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("ipCidrRange"), "ipCidrRange cannot be set on an IPV6_ONLY interface, use aliasIPRanges"))
+			}
+			if nic.SubnetworkRangeName != "" {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("subnetworkRangeName"), "subnetworkRangeName cannot be set on an IPV6_ONLY interface, use aliasIPRanges"))
+			}
 		}
 
 		switch nic.Ipv6AccessType {
@@ -130,21 +650,121 @@ func validateGCPNetworkInterfaces(interfaces []*api.GCPNetworkInterface, fldPath
 		}
 
 		// Validate IP CIDR RANGE
-		if nic.IpCidrRange != "" {
-			if err := validateIpCidrRange(nic.IpCidrRange); err != nil {
+		if nic.IpCidrRange != "" && !ipv6Only {
+			if err := validateIpCidrRange(nic.IpCidrRange, false); err != nil {
 				allErrs = append(allErrs, field.Invalid(idxPath.Child("ipCidrRange"), nic.IpCidrRange, err.Error()))
 			}
 		}
+		if len(nic.AliasIPRanges) != 0 && (nic.IpCidrRange != "" || nic.SubnetworkRangeName != "") {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("aliasIPRanges"), "aliasIPRanges cannot be combined with ipCidrRange and subnetworkRangeName"))
+		}
+		for j, aliasIPRange := range nic.AliasIPRanges {
+			rangePath := idxPath.Child("aliasIPRanges").Index(j)
+			if aliasIPRange.IpCidrRange == "" {
+				allErrs = append(allErrs, field.Required(rangePath.Child("ipCidrRange"), "ipCidrRange is required"))
+			} else if err := validateIpCidrRange(aliasIPRange.IpCidrRange, ipv6Only); err != nil {
+				allErrs = append(allErrs, field.Invalid(rangePath.Child("ipCidrRange"), aliasIPRange.IpCidrRange, err.Error()))
+			}
+		}
+
+		switch nic.NicType {
+		case "", api.GCPNicTypeGVNIC, api.GCPNicTypeVirtioNet:
+		default:
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("nicType"), nic.NicType, []string{api.GCPNicTypeGVNIC, api.GCPNicTypeVirtioNet}))
+		}
+		if tier1 && nic.NicType != api.GCPNicTypeGVNIC {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("nicType"), "TIER_1 egress bandwidth requires nicType GVNIC"))
+		}
+
+		if nic.QueueCount < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("queueCount"), nic.QueueCount, "must not be negative"))
+		}
+
+		if nic.NetworkIP != "" {
+			if ip := net.ParseIP(nic.NetworkIP); ip == nil || ip.To4() == nil {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("networkIP"), nic.NetworkIP, "must be a valid IPv4 address"))
+			}
+		}
+		allErrs = append(allErrs, validateGCPExternalIP(nic, idxPath)...)
 	}
 
 	return allErrs
 }
 
-// Function to validate CIDR range (e.g., "/xx" where xx is between 0 and 32)
-func validateIpCidrRange(cidrRange string) error {
-	// Check if the string starts with "/"
+func validateGCPExternalIP(nic *api.GCPNetworkInterface, fldPath *field.Path) []error {
+	var allErrs []error
+
+	if nic.DisableExternalIP {
+		if nic.NatIP != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("natIP"), "natIP cannot be set when disableExternalIP is true"))
+		}
+		if nic.NetworkTier != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("networkTier"), "networkTier cannot be set when disableExternalIP is true"))
+		}
+		if nic.PublicPtrDomainName != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("publicPtrDomainName"), "publicPtrDomainName cannot be set when disableExternalIP is true"))
+		}
+		return allErrs
+	}
+
+	if nic.StackType == api.GCPStackTypeIPv6Only {
+		// the external IPv6 address is not an access config with a network tier or a PTR record
+		if nic.NetworkTier != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("networkTier"), "networkTier cannot be set on an IPV6_ONLY interface"))
+		}
+		if nic.PublicPtrDomainName != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("publicPtrDomainName"), "publicPtrDomainName cannot be set on an IPV6_ONLY interface"))
+		}
+		return allErrs
+	}
+
+	if nic.NatIP != "" {
+		if ip := net.ParseIP(nic.NatIP); ip != nil && ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("natIP"), nic.NatIP, "must be a valid IPv4 address"))
+		} else if ip == nil && len(validation.IsDNS1035Label(nic.NatIP)) != 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("natIP"), nic.NatIP, "must be an IPv4 address or the name of a reserved external address"))
+		}
+	}
+
+	switch nic.NetworkTier {
+	case "", api.GCPNetworkTierPremium, api.GCPNetworkTierStandard:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("networkTier"), nic.NetworkTier, []string{api.GCPNetworkTierPremium, api.GCPNetworkTierStandard}))
+	}
+
+	if nic.PublicPtrDomainName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(strings.TrimSuffix(nic.PublicPtrDomainName, ".")) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("publicPtrDomainName"), nic.PublicPtrDomainName, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// Function to validate CIDR range (e.g., "/xx" where xx is between 0 and 32, or
+// between 0 and 128 for IPv6), an IP address or a full CIDR notation
+func validateIpCidrRange(cidrRange string, ipv6 bool) error {
+	maxMaskSize := 32
+	if ipv6 {
+		maxMaskSize = 128
+	}
+
+	// Check if the string starts with "/", otherwise accept a full IP or CIDR of the right family
 	if !strings.HasPrefix(cidrRange, "/") {
-		return fmt.Errorf("CIDR range must start with '/'")
+		ip := net.ParseIP(cidrRange)
+		if ip == nil {
+			var err error
+			if ip, _, err = net.ParseCIDR(cidrRange); err != nil {
+				return fmt.Errorf("CIDR range must start with '/' or be a valid IP address or CIDR")
+			}
+		}
+		if (ip.To4() == nil) != ipv6 {
+			if ipv6 {
+				return fmt.Errorf("CIDR range must be an IPv6 range on an IPV6_ONLY interface")
+			}
+			return fmt.Errorf("CIDR range must be an IPv4 range")
+		}
+		return nil
 	}
 
 	// Extract the mask size
@@ -154,9 +774,9 @@ func validateIpCidrRange(cidrRange string) error {
 		return fmt.Errorf("invalid CIDR mask size: %s", maskStr)
 	}
 
-	// Validate mask size (0 to 32 for IPv4)
-	if maskSize < 0 || maskSize > 32 {
-		return fmt.Errorf("CIDR mask size must be between 0 and 32")
+	// Validate mask size (0 to 32 for IPv4, 0 to 128 for IPv6)
+	if maskSize < 0 || maskSize > maxMaskSize {
+		return fmt.Errorf("CIDR mask size must be between 0 and %d", maxMaskSize)
 	}
 
 	return nil
@@ -186,6 +806,210 @@ func validateGCPScheduling(scheduling api.GCPScheduling, gpu *api.GCPGpu, fldPat
 		allErrs = append(allErrs, field.Forbidden(fldPath.Child("onHostMaintenance"), "liveMigration is not allowed for VMs with gpu attached, use \"TERMINATE\" instead"))
 	}
 
+	switch scheduling.ProvisioningModel {
+	case "", api.GCPProvisioningModelStandard:
+	case api.GCPProvisioningModelSpot:
+		if scheduling.AutomaticRestart {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("automaticRestart"), "automaticRestart must be false for Spot VMs"))
+		}
+		if scheduling.OnHostMaintenance != "TERMINATE" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("onHostMaintenance"), "liveMigration is not allowed for Spot VMs, use \"TERMINATE\" instead"))
+		}
+		if scheduling.Preemptible {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("preemptible"), "preemptible cannot be combined with the SPOT provisioning model"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("provisioningModel"), scheduling.ProvisioningModel, []string{api.GCPProvisioningModelStandard, api.GCPProvisioningModelSpot}))
+	}
+
+	switch scheduling.InstanceTerminationAction {
+	case "", api.GCPInstanceTerminationActionStop, api.GCPInstanceTerminationActionDelete:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("instanceTerminationAction"), scheduling.InstanceTerminationAction, []string{api.GCPInstanceTerminationActionStop, api.GCPInstanceTerminationActionDelete}))
+	}
+
+	if scheduling.MaxRunDuration != nil {
+		if scheduling.MaxRunDuration.Duration < minMaxRunDuration || scheduling.MaxRunDuration.Duration > maxMaxRunDuration {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxRunDuration"), scheduling.MaxRunDuration.Duration.String(), fmt.Sprintf("must be between %s and %s", minMaxRunDuration, maxMaxRunDuration)))
+		}
+		if scheduling.TerminationTime != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("terminationTime"), "terminationTime cannot be combined with maxRunDuration"))
+		}
+	}
+
+	timeLimited := scheduling.MaxRunDuration != nil || scheduling.TerminationTime != nil
+	if timeLimited && scheduling.InstanceTerminationAction == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("instanceTerminationAction"), "instanceTerminationAction is required when maxRunDuration or terminationTime is set"))
+	}
+	if !timeLimited && scheduling.InstanceTerminationAction != "" && scheduling.ProvisioningModel != api.GCPProvisioningModelSpot {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("instanceTerminationAction"), "instanceTerminationAction is only supported for Spot VMs or VMs with maxRunDuration or terminationTime"))
+	}
+
+	for i, nodeAffinity := range scheduling.NodeAffinities {
+		idxPath := fldPath.Child("nodeAffinities").Index(i)
+		if nodeAffinity.Key == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("key"), "key is required"))
+		}
+		if nodeAffinity.Operator != api.GCPNodeAffinityOperatorIn && nodeAffinity.Operator != api.GCPNodeAffinityOperatorNotIn {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("operator"), nodeAffinity.Operator, []string{api.GCPNodeAffinityOperatorIn, api.GCPNodeAffinityOperatorNotIn}))
+		}
+		if len(nodeAffinity.Values) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("values"), "at least one value is required"))
+		}
+	}
+
+	if scheduling.MinNodeCpus < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minNodeCpus"), scheduling.MinNodeCpus, "must not be negative"))
+	} else if scheduling.MinNodeCpus > 0 && len(scheduling.NodeAffinities) == 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("minNodeCpus"), "minNodeCpus is only supported for instances scheduled on sole-tenant nodes via nodeAffinities"))
+	}
+
+	return allErrs
+}
+
+func validateGCPCustomMachineType(customMachineType *api.GCPCustomMachineType, fldPath *field.Path) []error {
+	var allErrs []error
+
+	series, ok := customMachineTypeSeries[customMachineType.Series]
+	if !ok {
+		supported := make([]string, 0, len(customMachineTypeSeries))
+		for name := range customMachineTypeSeries {
+			supported = append(supported, name)
+		}
+		slices.Sort(supported)
+		return append(allErrs, field.NotSupported(fldPath.Child("series"), customMachineType.Series, supported))
+	}
+
+	if !series.validCpus(customMachineType.Cpus) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cpus"), customMachineType.Cpus, fmt.Sprintf("must be %s for series %s", series.cpusHint, customMachineType.Series)))
+		return allErrs
+	}
+
+	memory := customMachineType.MemoryMiB
+	if memory <= 0 || memory%256 != 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memoryMiB"), memory, "must be a positive multiple of 256"))
+		return allErrs
+	}
+
+	memoryPerCPU := float64(memory) / float64(customMachineType.Cpus)
+	if memoryPerCPU < series.minMemoryPerCPU {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memoryMiB"), memory, fmt.Sprintf("must be at least %.0f MiB per vCPU for series %s", series.minMemoryPerCPU, customMachineType.Series)))
+	}
+	if customMachineType.ExtendedMemory {
+		if series.maxExtendedMemory == 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("extendedMemory"), fmt.Sprintf("extended memory is not supported for series %s", customMachineType.Series)))
+		} else if memory > series.maxExtendedMemory {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("memoryMiB"), memory, fmt.Sprintf("must be at most %d MiB with extended memory for series %s", series.maxExtendedMemory, customMachineType.Series)))
+		}
+	} else if memoryPerCPU > series.maxMemoryPerCPU {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memoryMiB"), memory, fmt.Sprintf("must be at most %.0f MiB per vCPU for series %s, unless extendedMemory is enabled", series.maxMemoryPerCPU, customMachineType.Series)))
+	}
+	if series.maxMemory != 0 && memory > series.maxMemory {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memoryMiB"), memory, fmt.Sprintf("must be at most %d MiB for series %s", series.maxMemory, customMachineType.Series)))
+	}
+
+	return allErrs
+}
+
+func validateAdvancedMachineFeatures(features *api.AdvancedMachineFeatures, customMachineType *api.GCPCustomMachineType, fldPath *field.Path) []error {
+	var allErrs []error
+
+	if features == nil {
+		return allErrs
+	}
+
+	switch features.ThreadsPerCore {
+	case 0, 1, 2:
+	default:
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("threadsPerCore"), features.ThreadsPerCore, "must be either 1 or 2"))
+	}
+
+	// the visible cores are physical cores, each of them runs two vCPUs unless simultaneous multithreading is disabled
+	threadsPerCore := cmp.Or(features.ThreadsPerCore, 2)
+	if features.VisibleCoreCount < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("visibleCoreCount"), features.VisibleCoreCount, "must not be negative"))
+	} else if customMachineType != nil && (threadsPerCore == 1 || threadsPerCore == 2) {
+		if cores := customMachineType.Cpus / threadsPerCore; features.VisibleCoreCount > cores {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("visibleCoreCount"), features.VisibleCoreCount, fmt.Sprintf("must not exceed the %d cores of the custom machine type with %d vCPUs and %d threads per core", cores, customMachineType.Cpus, threadsPerCore)))
+		}
+	}
+
+	switch features.TurboMode {
+	case "", api.GCPTurboModeAllCoreMax:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("turboMode"), features.TurboMode, []string{api.GCPTurboModeAllCoreMax}))
+	}
+
+	switch features.PerformanceMonitoringUnit {
+	case "", api.GCPPerformanceMonitoringUnitArchitectural, api.GCPPerformanceMonitoringUnitStandard, api.GCPPerformanceMonitoringUnitEnhanced:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("performanceMonitoringUnit"), features.PerformanceMonitoringUnit, []string{api.GCPPerformanceMonitoringUnitArchitectural, api.GCPPerformanceMonitoringUnitStandard, api.GCPPerformanceMonitoringUnitEnhanced}))
+	}
+
+	return allErrs
+}
+
+func validateConfidentialInstanceConfig(config *api.ConfidentialInstanceConfig, machineType string, scheduling api.GCPScheduling, fldPath *field.Path) []error {
+	var allErrs []error
+
+	if config == nil {
+		return allErrs
+	}
+
+	series, ok := confidentialInstanceTypeSeries[config.ConfidentialInstanceType]
+	if !ok {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("confidentialInstanceType"), config.ConfidentialInstanceType, []string{api.GCPConfidentialInstanceTypeSEV, api.GCPConfidentialInstanceTypeSEVSNP, api.GCPConfidentialInstanceTypeTDX}))
+	} else if machineSeries, _, _ := strings.Cut(machineType, "-"); machineType != "" && !slices.Contains(series, machineSeries) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("confidentialInstanceType"), fmt.Sprintf("%s is not supported by machine type %q, supported machine series are %s", config.ConfidentialInstanceType, machineType, strings.Join(series, ", "))))
+	}
+
+	if scheduling.OnHostMaintenance != "TERMINATE" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Root().Child("scheduling", "onHostMaintenance"), "liveMigration is not allowed for confidential VMs, use \"TERMINATE\" instead"))
+	}
+
+	return allErrs
+}
+
+func validateGCPReservationAffinity(affinity *api.GCPReservationAffinity, fldPath *field.Path) []error {
+	var allErrs []error
+
+	if affinity == nil {
+		return allErrs
+	}
+
+	switch affinity.ConsumeReservationType {
+	case api.GCPConsumeSpecificReservation:
+		if len(affinity.Values) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("values"), "at least one reservation is required for SPECIFIC_RESERVATION"))
+		}
+		for i, value := range affinity.Values {
+			if !reservationValueRegex.MatchString(value) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("values").Index(i), value, "must be a reservation name or of the form projects/<project>/reservations/<name>"))
+			}
+		}
+	case api.GCPConsumeAnyReservation, api.GCPConsumeNoReservation:
+		if affinity.Key != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("key"), "key is only allowed for SPECIFIC_RESERVATION"))
+		}
+		if len(affinity.Values) != 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("values"), "values are only allowed for SPECIFIC_RESERVATION"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("consumeReservationType"), affinity.ConsumeReservationType, []string{api.GCPConsumeAnyReservation, api.GCPConsumeSpecificReservation, api.GCPConsumeNoReservation}))
+	}
+
+	return allErrs
+}
+
+func validateResourcePolicies(resourcePolicies []string, fldPath *field.Path) []error {
+	var allErrs []error
+
+	for i, resourcePolicy := range resourcePolicies {
+		if !resourcePolicyRegex.MatchString(resourcePolicy) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), resourcePolicy, "must be a resource policy name or a URL of the form projects/<project>/regions/<region>/resourcePolicies/<name>"))
+		}
+	}
+
 	return allErrs
 }
 